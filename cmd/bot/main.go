@@ -3,19 +3,39 @@ package main
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/aidosgal/lenshub/internal/bot"
 	"github.com/aidosgal/lenshub/internal/config"
+	"github.com/aidosgal/lenshub/internal/httpserver"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/metrics"
 	"github.com/aidosgal/lenshub/internal/repository"
 	"github.com/aidosgal/lenshub/internal/service"
     _ "github.com/lib/pq"
 )
 
+const (
+	envLocal = "local"
+	envDev   = "dev"
+	envProd  = "prod"
+)
 
 func main() {
     cfg := config.MustLoad()
 
+    log := setupLogger(cfg.Env, cfg.LogLevel)
+
+    if err := i18n.LoadTemplates(cfg.TemplatesDir); err != nil {
+        panic(err)
+    }
+    go reloadTemplatesOnHangup(log, cfg.TemplatesDir)
+
 	postgresURL := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.Database.User,
@@ -34,11 +54,84 @@ func main() {
     userRepository := repository.NewUserRepository(db)
     userService := service.NewUserService(userRepository)
 
-    orderService := service.NewOrderService(db)
+    // Without admins nobody could approve held orders, so skip moderation.
+    moderateFirst := cfg.Moderation.FirstOrders
+    if len(cfg.Admins) == 0 {
+        moderateFirst = 0
+    }
+    orderService := service.NewOrderService(db, moderateFirst)
 
     responseService := service.NewResponseService(db)
-    bot := bot.NewTgBot(cfg.Telegram, userService, orderService, responseService)
+    adminService := service.NewAdminService(db)
+    reportService := service.NewReportService(db)
+    linkService := service.NewLinkService(db)
+    reviewService := service.NewReviewService(db)
+    channelService := service.NewChannelService(db)
+    groupService := service.NewGroupService(db)
+    teamService := service.NewTeamService(db)
+    authzService := service.NewAuthzService(userService, orderService)
 
-    log.Print("bot starting...")
+    m := metrics.New()
+    bot := bot.NewTgBot(cfg.Telegram, bot.Deps{
+        Users:     userService,
+        Orders:    orderService,
+        Responses: responseService,
+        Admin:     adminService,
+        Reports:   reportService,
+        Links:     linkService,
+        Reviews:   reviewService,
+        Channels:  channelService,
+        Groups:    groupService,
+        Teams:     teamService,
+        Authz:     authzService,
+    }, cfg.Admins, cfg.Channels, m, log)
+
+    if cfg.HTTPServer.Address != "" {
+        srv := httpserver.New(cfg.HTTPServer, db, bot, m.Handler(), log)
+        go func() {
+            log.Info("http server listening", slog.String("address", cfg.HTTPServer.Address))
+            if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+                log.Error("http server failed", sl.Err(err))
+                os.Exit(1)
+            }
+        }()
+    }
+
+    log.Info("bot starting...", slog.String("env", cfg.Env))
     bot.Start()
 }
+
+// reloadTemplatesOnHangup rereads the message templates on SIGHUP. Broken
+// templates are reported and the ones in use are kept.
+func reloadTemplatesOnHangup(log *slog.Logger, dir string) {
+    hangup := make(chan os.Signal, 1)
+    signal.Notify(hangup, syscall.SIGHUP)
+
+    for range hangup {
+        if err := i18n.LoadTemplates(dir); err != nil {
+            log.Error("failed to reload message templates", sl.Err(err))
+            continue
+        }
+        log.Info("message templates reloaded", slog.String("dir", dir))
+    }
+}
+
+// setupLogger writes human-readable text locally and JSON everywhere else.
+// Personal data attributes are redacted in every environment.
+func setupLogger(env, level string) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		ReplaceAttr: sl.RedactPII,
+	}
+
+	switch env {
+	case envLocal:
+		opts.Level = sl.ParseLevel(level, slog.LevelDebug)
+		return slog.New(slog.NewTextHandler(os.Stdout, opts))
+	case envDev:
+		opts.Level = sl.ParseLevel(level, slog.LevelDebug)
+	default:
+		opts.Level = sl.ParseLevel(level, slog.LevelInfo)
+	}
+
+	return slog.New(slog.NewJSONHandler(os.Stdout, opts))
+}
@@ -0,0 +1,328 @@
+package bot
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/markup"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+    AdminCommandStats      = "stats"
+    AdminCommandUser       = "user"
+    AdminCommandBan        = "ban"
+    AdminCommandUnban      = "unban"
+    AdminCommandOrder      = "order"
+    AdminCommandCloseOrder = "close_order"
+    AdminCommandBroadcast  = "broadcast"
+    AdminCommandReports    = "reports"
+    AdminCommandModeration = "moderation"
+    AdminCommandLinks      = "links"
+)
+
+// broadcastInterval keeps broadcasts below Telegram's limit of about 30
+// messages per second.
+const broadcastInterval = 50 * time.Millisecond
+
+func isAdminCommand(command string) bool {
+    switch command {
+    case AdminCommandStats, AdminCommandUser, AdminCommandBan, AdminCommandUnban,
+        AdminCommandOrder, AdminCommandCloseOrder, AdminCommandBroadcast, AdminCommandReports,
+        AdminCommandModeration, AdminCommandLinks:
+        return true
+    }
+    return false
+}
+
+func (tg *TgBot) isAdmin(chatID int64) bool {
+    _, ok := tg.admins[chatID]
+    return ok
+}
+
+func (tg *TgBot) handleAdminCommand(log *slog.Logger, message *tgbotapi.Message) {
+    chatID := message.Chat.ID
+    command := message.Command()
+    args := strings.TrimSpace(message.CommandArguments())
+    log = log.With(slog.String("handler", "handleAdminCommand"), slog.String("command", command))
+
+    target := args
+    if command == AdminCommandBroadcast {
+        target = ""
+    }
+    if err := tg.adminService.LogAction(strconv.FormatInt(chatID, 10), command, target, args); err != nil {
+        log.Error("failed to write audit log", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.audit_error")))
+        return
+    }
+    log.Info("admin command")
+
+    switch command {
+    case AdminCommandStats:
+        tg.adminStats(log, chatID)
+    case AdminCommandUser:
+        tg.adminUser(log, chatID, args)
+    case AdminCommandBan:
+        tg.adminSetBanned(log, chatID, args, true)
+    case AdminCommandUnban:
+        tg.adminSetBanned(log, chatID, args, false)
+    case AdminCommandOrder:
+        tg.adminOrder(log, chatID, args)
+    case AdminCommandCloseOrder:
+        tg.adminCloseOrder(log, chatID, args)
+    case AdminCommandBroadcast:
+        tg.adminBroadcast(log, chatID, args)
+    case AdminCommandReports:
+        tg.adminReports(log, chatID)
+    case AdminCommandModeration:
+        tg.adminModeration(log, chatID)
+    case AdminCommandLinks:
+        tg.adminLinks(log, chatID)
+    }
+}
+
+func (tg *TgBot) adminStats(log *slog.Logger, chatID int64) {
+    stats, err := tg.adminService.GetStats()
+    if err != nil {
+        log.Error("failed to get stats", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.stats_error")))
+        return
+    }
+
+    text := tg.t(chatID, "admin.stats",
+        stats.Customers,
+        stats.Executors,
+        stats.BannedUsers,
+        stats.OpenOrders,
+        stats.TotalOrders,
+        stats.OrdersToday,
+        stats.Responses,
+    )
+    tg.send(log, tgbotapi.NewMessage(chatID, text))
+}
+
+// linkStatsLimit is how many start links /links lists.
+const linkStatsLimit = 20
+
+// adminLinks lists the start links that brought in most users.
+func (tg *TgBot) adminLinks(log *slog.Logger, chatID int64) {
+    links, err := tg.linkService.GetLinkStats(linkStatsLimit)
+    if err != nil {
+        log.Error("failed to get link stats", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.links_error")))
+        return
+    }
+    if len(links) == 0 {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.links_empty")))
+        return
+    }
+
+    var text strings.Builder
+    text.WriteString(tg.t(chatID, "admin.links"))
+    for _, link := range links {
+        text.WriteString("\n")
+        text.WriteString(tg.t(chatID, "admin.links_row", link.Payload, link.Visits, link.Visitors, link.SignUps))
+    }
+    tg.send(log, tgbotapi.NewMessage(chatID, text.String()))
+}
+
+// adminFailure tells an admin why a command failed. Missing targets and
+// targets in the wrong state are explained; anything else is logged and
+// reported with the message key, keeping database errors out of the chat.
+func (tg *TgBot) adminFailure(log *slog.Logger, chatID int64, err error, key string, args ...any) {
+    switch {
+    case errors.Is(err, errs.ErrNotFound):
+        log.Info("admin command target not found", sl.Err(err))
+        key, args = "admin.not_found", nil
+    case errors.Is(err, errs.ErrInvalidState):
+        log.Info("admin command refused", sl.Err(err))
+        key, args = "admin.invalid_state", nil
+    default:
+        log.Error("admin command failed", sl.Err(err))
+    }
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, key, args...)))
+}
+
+func (tg *TgBot) adminUser(log *slog.Logger, chatID int64, args string) {
+    if args == "" {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.user_usage")))
+        return
+    }
+
+    user, err := tg.service.GetUserByChatID(args)
+    if errors.Is(err, errs.ErrNotFound) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.user_not_found")))
+        return
+    }
+    if err != nil {
+        log.Error("failed to get user", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.user_error")))
+        return
+    }
+
+    banned := markup.Safe(tg.t(chatID, "admin.no"))
+    if user.Banned {
+        banned = markup.Safe(tg.t(chatID, "admin.yes"))
+    }
+
+    roles := make([]string, 0, len(user.Roles))
+    for _, role := range user.Roles {
+        name := roleName(tg.lang(chatID), role)
+        if role == user.Role && len(user.Roles) > 1 {
+            name += " ✓"
+        }
+        roles = append(roles, name)
+    }
+
+    text := tg.t(chatID, "admin.user",
+        user.Id,
+        user.ChatId,
+        user.Name,
+        user.UserName,
+        strings.Join(roles, ", "),
+        specializationName(tg.lang(chatID), user.Specialization),
+        user.Portfolio,
+        banned,
+    )
+    tg.send(log, tgbotapi.NewMessage(chatID, text))
+}
+
+func (tg *TgBot) adminSetBanned(log *slog.Logger, chatID int64, args string, banned bool) {
+    if args == "" {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.ban_usage")))
+        return
+    }
+
+    var err error
+    if banned {
+        err = tg.banUser(log, args)
+    } else {
+        err = tg.service.SetBanned(args, false)
+    }
+    if err != nil {
+        tg.adminFailure(log.With(slog.String("action", "set_banned")), chatID, err, "admin.ban_error")
+        return
+    }
+
+    text := tg.t(chatID, "admin.banned", args)
+    if !banned {
+        text = tg.t(chatID, "admin.unbanned", args)
+    }
+    tg.send(log, tgbotapi.NewMessage(chatID, text))
+}
+
+// banUser blocks a user and cancels their live orders, so that executors
+// can no longer respond to them and the channels drop their posts. Only a
+// failure to block is returned: the ban stands even if the orders could
+// not be cleaned up.
+func (tg *TgBot) banUser(log *slog.Logger, chatID string) error {
+    if err := tg.service.SetBanned(chatID, true); err != nil {
+        return err
+    }
+
+    user, err := tg.service.GetUserByChatID(chatID)
+    if err != nil {
+        log.Error("failed to get banned user", sl.Err(err))
+        return nil
+    }
+    orders, err := tg.orderService.CancelUserOrders(user.Id)
+    if err != nil {
+        log.Error("failed to cancel orders of banned user", sl.Err(err))
+        return nil
+    }
+
+    log.Info("orders of banned user cancelled", slog.Int("count", len(orders)))
+    for i := range orders {
+        tg.deleteChannelPosts(log, &orders[i])
+    }
+    return nil
+}
+
+func (tg *TgBot) adminOrder(log *slog.Logger, chatID int64, args string) {
+    if args == "" {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.order_usage")))
+        return
+    }
+
+    order, err := tg.orderService.GetOrderByID(args)
+    if err != nil {
+        tg.adminFailure(log.With(slog.String("action", "get_order")), chatID, err, "admin.order_error")
+        return
+    }
+
+    text := tg.t(chatID, "admin.order",
+        order.ID,
+        order.Status,
+        order.Title,
+        orderSpecialization(tg.lang(chatID), &order),
+        order.Description,
+        order.Location,
+        order.CreatedAt.Format("02.01.2006 15:04"),
+        order.User.Name,
+        order.User.UserName,
+        order.User.ChatId,
+    )
+    tg.send(log, tgbotapi.NewMessage(chatID, text))
+}
+
+func (tg *TgBot) adminCloseOrder(log *slog.Logger, chatID int64, args string) {
+    if args == "" {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.close_order_usage")))
+        return
+    }
+
+    if err := tg.orderService.CloseOrder(args); err != nil {
+        tg.adminFailure(log.With(slog.String("action", "close_order")), chatID, err, "admin.close_order_error")
+        return
+    }
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.order_closed", args)))
+
+    order, err := tg.orderService.GetOrderByID(args)
+    if err != nil {
+        log.Error("failed to get order", sl.Err(err))
+        return
+    }
+    tg.updateChannelPosts(log, &order)
+}
+
+func (tg *TgBot) adminBroadcast(log *slog.Logger, chatID int64, text string) {
+    if text == "" {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.broadcast_usage")))
+        return
+    }
+
+    chatIDs, err := tg.service.GetActiveChatIDs()
+    if err != nil {
+        log.Error("failed to get recipients", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.broadcast_error")))
+        return
+    }
+
+    tg.send(log, tgbotapi.NewMessage(chatID, i18n.N(tg.lang(chatID), "admin.broadcast_started", len(chatIDs), len(chatIDs))))
+
+    // Sending is paced, so run it outside the update loop.
+    go func() {
+        var delivered int
+        for _, id := range chatIDs {
+            recipient, err := strconv.ParseInt(id, 10, 64)
+            if err != nil {
+                continue
+            }
+            // The text goes out exactly as the admin typed it.
+            if _, err := tg.send(log, tgbotapi.NewMessage(recipient, markup.Escape(markup.HTML, text))); err == nil {
+                delivered++
+            }
+            time.Sleep(broadcastInterval)
+        }
+
+        log.Info("broadcast finished", slog.Int("recipients", len(chatIDs)), slog.Int("delivered", delivered))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.broadcast_finished", delivered, len(chatIDs))))
+    }()
+}
@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	"github.com/aidosgal/lenshub/internal/service"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// refusals maps the reasons the services refuse an action to the messages
+// explaining them. Specific reasons come first, since they wrap the generic
+// errs sentinels at the end.
+var refusals = []struct {
+    err error
+    key string
+}{
+    {service.ErrNotRegistered, "authz.not_registered"},
+    {service.ErrBanned, "banned"},
+    {service.ErrNotOrderOwner, "authz.not_owner"},
+    {service.ErrOrderNotOpen, "authz.order_not_open"},
+    {service.ErrOwnOrder, "authz.own_order"},
+    {service.ErrSpecializationMismatch, "authz.specialization_mismatch"},
+    {service.ErrNotInTeam, "authz.not_in_team"},
+    {service.ErrTeamRoleRequired, "authz.team_role"},
+    {service.ErrSlotFilled, "authz.slot_filled"},
+    {service.ErrTeamHasOrders, "team.has_open_orders"},
+    {service.ErrSelfReport, "report.self"},
+    {errs.ErrNotFound, "error.not_found"},
+    {errs.ErrInvalidState, "error.invalid_state"},
+    {errs.ErrForbidden, "error.forbidden"},
+}
+
+// refuse tells the user why an action failed: the reason of a refusal, or
+// a generic error for anything else, which is logged.
+func (tg *TgBot) refuse(log *slog.Logger, chatID int64, err error) {
+    var roleErr *service.RoleRequiredError
+    if errors.As(err, &roleErr) {
+        log.Info("action requires a role the user does not hold", slog.String("role", string(roleErr.Role)))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "role.required", roleName(tg.lang(chatID), roleErr.Role))))
+        return
+    }
+
+    for _, r := range refusals {
+        if errors.Is(err, r.err) {
+            log.Info("action refused", sl.Err(err))
+            tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, r.key)))
+            return
+        }
+    }
+
+    log.Error("failed to authorize action", sl.Err(err))
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+}
+
+// authorizeRole returns the acting user if they hold the role.
+func (tg *TgBot) authorizeRole(log *slog.Logger, chatID int64, role model.Role) (*model.User, bool) {
+    user, err := tg.authz.RequireRole(strconv.FormatInt(chatID, 10), role)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return nil, false
+    }
+    return user, true
+}
+
+// ownOrder loads an order the acting customer may manage.
+func (tg *TgBot) ownOrder(log *slog.Logger, chatID int64, orderID string) (model.Order, bool) {
+    _, order, err := tg.authz.CanManageOrder(strconv.FormatInt(chatID, 10), orderID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return model.Order{}, false
+    }
+    return order, true
+}
+
+// authorizeResponse loads the acting executor and the order they want to
+// respond to.
+func (tg *TgBot) authorizeResponse(log *slog.Logger, chatID int64, orderID string) (*model.User, model.Order, bool) {
+    executor, order, err := tg.authz.CanRespond(strconv.FormatInt(chatID, 10), orderID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return nil, model.Order{}, false
+    }
+    return executor, order, true
+}
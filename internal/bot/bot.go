@@ -1,30 +1,115 @@
 package bot
 
 import (
-	"fmt"
-	"log"
+	"errors"
+	"log/slog"
 	"strconv"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/aidosgal/lenshub/internal/callback"
+	"github.com/aidosgal/lenshub/internal/config"
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/metrics"
 	"github.com/aidosgal/lenshub/internal/model"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type UserService interface {
 	CreateUser(model.User) error
+    AddRole(user model.User) error
+    SetActiveRole(chatID string, role model.Role) error
     GetUserByChatID(chatID string) (*model.User, error)
-    GetUsersBySpecialization(specialization string) ([]model.User, error)
+    GetUserByID(id int) (*model.User, error)
+    GetUsersBySpecialization(specialization model.Specialization) ([]model.User, error)
+    SearchExecutors(text string, specializations []model.Specialization, limit int) ([]model.User, error)
+    SetBanned(chatID string, banned bool) error
+    SetCities(chatID string, cities []string) error
+    GetActiveChatIDs() ([]string, error)
+    SetLanguage(chatID string, language string) error
 }
 
 type OrderService interface {
     CreateOrder(order model.Order) (model.Order, error)
     GetOrderByID(orderID string) (model.Order, error)
+    CloseOrder(orderID string) error
+    CancelOrder(orderID string) error
+    UpdateOrder(order model.Order) (string, error)
+    PublishOrder(orderID string) (model.Order, error)
+    CancelUserOrders(userID int) ([]model.Order, error)
+    RepostOrder(orderID string) (model.Order, error)
+    GetOrdersByUser(userID int, limit int) ([]model.Order, error)
+    ApproveOrder(orderID string) (edited bool, err error)
+    RejectOrder(orderID string) error
+    GetPendingOrders(limit int) ([]model.Order, error)
+    HireExecutor(orderID string, executorID int) error
+    SetSlot(orderID string, spec model.Specialization, headcount int) error
+    SearchOpenOrders(text string, specializations []model.Specialization, limit int) ([]model.Order, error)
+}
+
+type ReviewService interface {
+    RateExecutor(orderID, customerID, executorID, rating int) error
+    GetExecutorStats(executorID int) (model.ExecutorStats, error)
 }
 
 type OrderResponseService interface {
-    CreateOrderResponse(orderID string, executorID int) error
+    CreateOrderResponse(orderID string, executorID, teamID int, message string) error
+    GetResponders(orderID int) ([]model.User, error)
+}
+
+type AuthzService interface {
+    Actor(chatID string) (*model.User, error)
+    RequireRole(chatID string, role model.Role) (*model.User, error)
+    CanManageOrder(chatID, orderID string) (*model.User, model.Order, error)
+    CanRespond(chatID, orderID string) (*model.User, model.Order, error)
+    TeamMember(chatID string, roles ...model.TeamRole) (*model.User, error)
+}
+
+type ReportService interface {
+    CreateReport(reporterID, targetID, orderID int) (bool, error)
+    GetReportByID(reportID int) (model.Report, error)
+    GetPendingReports(limit int) ([]model.Report, error)
+    ResolveReport(reportID int, status, adminChatID string) error
+}
+
+type LinkService interface {
+    RecordVisit(chatID, payload, kind string, registered bool) error
+    GetLinkStats(limit int) ([]model.LinkStats, error)
+}
+
+type ChannelService interface {
+    SavePost(post model.ChannelPost) error
+    GetPosts(orderID int) ([]model.ChannelPost, error)
+    DeletePost(post model.ChannelPost) error
+}
+
+type GroupService interface {
+    LinkGroup(group model.GroupChat, linkedBy int64) error
+    UnlinkGroup(chatID int64) (bool, error)
+    MoveGroup(oldChatID, newChatID int64) error
+    GetGroup(chatID int64) (model.GroupChat, error)
+    GetGroupsByUser(userID int) ([]model.GroupChat, error)
+}
+
+type TeamService interface {
+    CreateTeam(name string, ownerID int) (model.Team, error)
+    GetTeam(teamID int) (model.Team, error)
+    GetTeamByInviteCode(code string) (model.Team, error)
+    AddMember(teamID, userID int) error
+    RemoveMember(teamID, userID int) error
+    SetMemberRole(teamID, userID int, role model.TeamRole) error
+    DisbandTeam(teamID int) error
+    AssignMember(orderID string, teamID, userID int) error
+    GetTeamStats(teamID int) (model.ExecutorStats, error)
+}
+
+type AdminService interface {
+    GetStats() (model.Stats, error)
+    LogAction(adminChatID, action, target, details string) error
 }
 
 type TgBot struct {
@@ -32,602 +117,582 @@ type TgBot struct {
 	service    UserService
     orderService OrderService
     orderResponseService OrderResponseService
-	userStates map[int64]string // Tracks the state of users
-	userData   map[int64]*model.User
-    orderData   map[int64]*model.Order
+    adminService AdminService
+    reportService ReportService
+    linkService LinkService
+    reviewService ReviewService
+    channelService ChannelService
+    groupService GroupService
+    teamService TeamService
+    authz      AuthzService
+    admins     map[int64]struct{}
+    channels   []config.ChannelConfig // Channels new orders are posted to
+    flows      *fsm.Machine // Wizards each chat is going through
+    callbacks  *callback.Codec
+    routes     map[string]callbackRoute
+    screens    map[int64]int // Message ID of each chat's interactive screen
+    latest     map[int64]int // ID of the newest message in each chat
+    langs      map[int64]i18n.Lang // Interface language of each chat
 	stateMutex sync.Mutex
+    metrics    *metrics.Metrics
+    log        *slog.Logger
+    lastPoll   atomic.Int64 // Unix time of the last successful getUpdates
 }
 
 const (
-	StateChoosingRole           = "choosing_role"
-	StateEnteringPortfolio      = "entering_portfolio"
-	StateChoosingSpecialization = "choosing_specialization"
-	StateIdle                   = "idle"
-    StateEnteringOrderTitle       = "entering_order_title"
-    StateEnteringOrderDescription = "entering_order_description"
-    StateEnteringOrderLocation    = "entering_order_location"
-    StateChoosingOrderSpecialization = "choosing_order_specialization"
+    pollTimeout       = 60
+    pollRetryInterval = 3 * time.Second
 )
 
-func NewTgBot(token string, service UserService, order OrderService, orderOrderResponseService OrderResponseService) *TgBot {
+// Deps are the services the bot works with. They are named, so that two
+// services behind the same interface cannot be swapped unnoticed.
+type Deps struct {
+    Users     UserService
+    Orders    OrderService
+    Responses OrderResponseService
+    Admin     AdminService
+    Reports   ReportService
+    Links     LinkService
+    Reviews   ReviewService
+    Channels  ChannelService
+    Groups    GroupService
+    Teams     TeamService
+    Authz     AuthzService
+}
+
+func NewTgBot(token string, deps Deps, admins []int64, channels []config.ChannelConfig, metrics *metrics.Metrics, log *slog.Logger) *TgBot {
 	bot, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
 		panic(err)
 	}
-	return &TgBot{
+
+    adminSet := make(map[int64]struct{}, len(admins))
+    for _, id := range admins {
+        adminSet[id] = struct{}{}
+    }
+
+	tg := &TgBot{
 		bot:        *bot,
-		service:    service,
-        orderService: order,
-        orderResponseService: orderOrderResponseService,
-		userStates: make(map[int64]string),
-		userData:   make(map[int64]*model.User),
-		orderData:   make(map[int64]*model.Order),
+		service:    deps.Users,
+        orderService: deps.Orders,
+        orderResponseService: deps.Responses,
+        adminService: deps.Admin,
+        reportService: deps.Reports,
+        linkService: deps.Links,
+        reviewService: deps.Reviews,
+        channelService: deps.Channels,
+        groupService: deps.Groups,
+        teamService: deps.Teams,
+        authz:      deps.Authz,
+        admins:     adminSet,
+        channels:   channels,
+        flows:      newFlows(),
+        screens:    make(map[int64]int),
+        latest:     make(map[int64]int),
+        langs:      make(map[int64]i18n.Lang),
+        metrics:    metrics,
+        log:        log,
+        callbacks:  callback.NewCodec(callbackSecret(token)),
 	}
+	tg.routes = tg.callbackRoutes()
+	return tg
 }
 
 func (tg *TgBot) Start() {
 	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
-
-	updates := tg.bot.GetUpdatesChan(u)
-
-	for update := range updates {
-        log.Printf("Received update: %+v\n", update)
-		if update.Message != nil {
-			tg.handleMessage(update.Message)
+	u.Timeout = pollTimeout
+
+	// Poll manually instead of using GetUpdatesChan so that successful
+	// getUpdates calls can be tracked for the readiness probe.
+	for {
+		updates, err := tg.bot.GetUpdates(u)
+		if err != nil {
+			tg.log.Error("failed to get updates", sl.Err(err), slog.Duration("retry_in", pollRetryInterval))
+			tg.metrics.TelegramPollErrors.Inc()
+			time.Sleep(pollRetryInterval)
+			continue
 		}
+		tg.lastPoll.Store(time.Now().Unix())
 
-		if update.CallbackQuery != nil {
-			tg.handleCallbackQuery(update.CallbackQuery)
+		for _, update := range updates {
+			if update.UpdateID >= u.Offset {
+				u.Offset = update.UpdateID + 1
+			}
+			tg.handleUpdate(update)
 		}
 	}
 }
 
-func (tg *TgBot) handleMessage(message *tgbotapi.Message) {
-	chatID := message.Chat.ID
-    chat_id := strconv.Itoa(int(chatID))
+// LastPoll reports when getUpdates last succeeded, or the zero time if it
+// never has.
+func (tg *TgBot) LastPoll() time.Time {
+	ts := tg.lastPoll.Load()
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
 
-	tg.stateMutex.Lock()
-	state := tg.userStates[chatID]
-	tg.stateMutex.Unlock()
+func (tg *TgBot) handleUpdate(update tgbotapi.Update) {
+	log := tg.log.With(slog.Int("update_id", update.UpdateID))
+	if chat := update.FromChat(); chat != nil {
+		log = log.With(slog.Int64("chat_id", chat.ID))
+	}
 
-	if message.Text == "/start" {
-        if user, err := tg.service.GetUserByChatID(chat_id); err == nil && user != nil {
-            tg.showUserProfile(chatID, user)
-            return
-        }
-		tg.stateMutex.Lock()
-		tg.userStates[message.Chat.ID] = StateChoosingRole
-		tg.stateMutex.Unlock()
+	var updateType string
+	// Named before handling: a flow that ends with this update is gone
+	// afterwards.
+	handler := tg.handlerName(update)
+	start := time.Now()
+
+	switch {
+	case update.Message != nil:
+		updateType = "message"
+		log.Debug("received update", slog.String("type", updateType))
+		tg.handleMessage(log, update.Message)
+	case update.CallbackQuery != nil:
+		updateType = "callback_query"
+		log.Debug("received update", slog.String("type", updateType), slog.String("data", update.CallbackQuery.Data))
+		tg.handleCallbackQuery(log, update.CallbackQuery)
+	case update.InlineQuery != nil:
+		updateType = "inline_query"
+		log.Debug("received update", slog.String("type", updateType))
+		tg.handleInlineQuery(log, update.InlineQuery)
+	default:
+		updateType = "other"
+		log.Debug("ignoring update", slog.String("type", updateType))
+	}
 
-        welcomeText := `👋 Добро пожаловать в LensHub!
+	tg.metrics.UpdatesProcessed.Inc(updateType)
+	tg.metrics.HandlerDuration.Observe(time.Since(start).Seconds(), handler)
+}
 
-Мы соединяем талантливых фотографов и видеографов с заказчиками.
+// handlerName labels an update by what handles it: the command, the
+// callback action or the flow a message answers. Unknown commands and
+// actions share a label, so the set of labels stays bounded.
+func (tg *TgBot) handlerName(update tgbotapi.Update) string {
+	switch {
+	case update.Message != nil:
+		message := update.Message
+		if isGroupChat(message.Chat) {
+			return "group_message"
+		}
+		if message.IsCommand() {
+			command := message.Command()
+			switch {
+			case command == CommandStart, command == CommandCancel, command == CommandLanguage, isAdminCommand(command):
+				return "command_" + command
+			}
+			return "command_unknown"
+		}
+		if flow, _, ok := tg.flows.Active(message.Chat.ID); ok {
+			return "flow_" + flow
+		}
+		return "message"
+	case update.CallbackQuery != nil:
+		query := update.CallbackQuery
+		if query.Message == nil {
+			return "callback_invalid"
+		}
+		payload, err := tg.callbacks.Decode(query.Message.Chat.ID, query.Data)
+		if err != nil {
+			return "callback_invalid"
+		}
+		if _, ok := tg.routes[payload.Action]; !ok {
+			return "callback_unknown"
+		}
+		return "callback_" + payload.Action
+	case update.InlineQuery != nil:
+		return "inline_query"
+	}
+	return "other"
+}
 
-Выберите вашу роль для начала работы:`
+// send delivers a message through the Bot API, logging and counting failed
+// requests. Texts are HTML built with the markup package unless a message
+// sets another parse mode; a text Telegram cannot parse is sent again as
+// plain text rather than lost.
+func (tg *TgBot) send(log *slog.Logger, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	c = withParseMode(c)
+	msg, err := tg.bot.Send(c)
+	if err != nil && isEntityParseError(err) {
+		if plain, ok := plainText(c); ok {
+			log.Warn("failed to parse message entities, sending plain text", sl.Err(err))
+			msg, err = tg.bot.Send(plain)
+		}
+	}
+	if err != nil {
+		tg.metrics.TelegramSendErrors.Inc()
+		log.Warn("failed to send message", sl.Err(err))
+	} else if msg.Chat != nil {
+		tg.noteMessage(msg.Chat.ID, msg.MessageID)
+	}
+	return msg, err
+}
 
-		buttons := [][]tgbotapi.InlineKeyboardButton{
-            {
-                tgbotapi.NewInlineKeyboardButtonData("🤝 Я заказчик", "role_customer"),
-                tgbotapi.NewInlineKeyboardButtonData("📸 Я исполнитель", "role_executor"),
-            },
-        }
-		keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
+func (tg *TgBot) handleMessage(log *slog.Logger, message *tgbotapi.Message) {
+	log = log.With(slog.String("handler", "handleMessage"))
+	chatID := message.Chat.ID
+	tg.noteMessage(chatID, message.MessageID)
 
-        response := tgbotapi.NewMessage(message.Chat.ID, welcomeText)
-        response.ReplyMarkup = keyboard
-        tg.bot.Send(response)
+	if isGroupChat(message.Chat) {
+		tg.handleGroupMessage(log, message)
+		return
 	}
 
-	switch state {
-	case StateEnteringPortfolio:
-		tg.handlePortfolioInput(message)
-    case StateEnteringOrderTitle:
-        tg.handleOrderTitleInput(message)
-    case StateEnteringOrderDescription:
-        tg.handleOrderDescriptionInput(message)
-    case StateEnteringOrderLocation:
-        tg.handleOrderLocationInput(message)
-	default:
+	user := tg.currentUser(log, chatID)
+	tg.rememberLang(chatID, message.From, user)
+	if tg.rejectBanned(log, chatID, user) {
+		return
+	}
+
+	if message.IsCommand() && isAdminCommand(message.Command()) {
+		// Admin commands are invisible to everyone else.
+		if tg.isAdmin(chatID) {
+			tg.handleAdminCommand(log, message)
+		}
+		return
+	}
+
+    // Commands are never taken as answers to the current wizard step.
+    if message.IsCommand() {
+        switch message.Command() {
+        case CommandStart:
+            tg.handleStart(log, chatID, message.From, user, message.CommandArguments())
+        case CommandCancel:
+            tg.handleCancel(log, chatID, user)
+        case CommandLanguage:
+            tg.handleLanguageCommand(log, chatID)
+        }
+        return
+    }
+
+    if !tg.handleFlowInput(log, message) {
 		//response := tgbotapi.NewMessage(chatID, "Используйте /start для начала регистрации.")
 		//tg.bot.Send(response)
-	}
+    }
 }
 
-func (tg *TgBot) showUserProfile(chatID int64, user *model.User) {
+func (tg *TgBot) showUserProfile(log *slog.Logger, chatID int64, user *model.User) {
     var profileText string
     var buttons [][]tgbotapi.InlineKeyboardButton
 
-    if user.Role == "Заказчик" {
-        profileText = fmt.Sprintf(`👤 *Ваш профиль*
-        
-📋 *Роль:* Заказчик
-👤 *Имя:* %s
-🔍 *Username:* @%s
-
-Что бы вы хотели сделать?`, user.Name, user.UserName)
+    if user.Role == model.RoleCustomer {
+        profileText = tg.r(chatID, "profile.customer", i18n.Vars{"Name": user.Name, "UserName": user.UserName})
 
         buttons = [][]tgbotapi.InlineKeyboardButton{
             {
-                tgbotapi.NewInlineKeyboardButtonData("📝 Создать заказ", "create_order"),
-                tgbotapi.NewInlineKeyboardButtonData("📋 Мои заказы", "my_orders"),
+                tg.button(chatID, tg.t(chatID, "button.create_order"), CallbackCreateOrder),
+                tg.button(chatID, tg.t(chatID, "button.my_orders"), CallbackMyOrders),
             },
         }
     } else {
-        profileText = fmt.Sprintf(`👤 *Ваш профиль*
-        
-📸 *Роль:* Исполнитель
-👤 *Имя:* %s
-🔍 *Username:* @%s
-🎯 *Специализация:* %s
-
-Что бы вы хотели сделать?`, user.Name, user.UserName, user.Specialization)
+        profileText = tg.r(chatID, "profile.executor", i18n.Vars{
+            "Name":           user.Name,
+            "UserName":       user.UserName,
+            "Specialization": specializationName(tg.lang(chatID), user.Specialization),
+        })
 
         buttons = [][]tgbotapi.InlineKeyboardButton{
             {
-                tgbotapi.NewInlineKeyboardButtonURL("🎨 Моё портфолио", user.Portfolio),
+                tgbotapi.NewInlineKeyboardButtonURL(tg.t(chatID, "button.my_portfolio"), user.Portfolio),
+            },
+            {
+                tg.button(chatID, tg.t(chatID, "button.edit_cities"), CallbackEditCities),
+                tgbotapi.NewInlineKeyboardButtonSwitch(tg.t(chatID, "button.share_profile"), startLink{LinkExecutor, strconv.Itoa(user.Id)}.String()),
+            },
+            {
+                tg.button(chatID, tg.t(chatID, "button.team"), CallbackTeam),
+                tgbotapi.NewInlineKeyboardButtonURL(tg.t(chatID, "button.add_to_group"), tg.startGroupURL()),
             },
         }
     }
 
+    buttons = append(buttons,
+        tg.roleSwitcherRow(chatID, user),
+        tgbotapi.NewInlineKeyboardRow(tg.inviteButton(chatID, user)),
+    )
+
     keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
     msg := tgbotapi.NewMessage(chatID, profileText)
-    msg.ParseMode = "Markdown"
     msg.ReplyMarkup = keyboard
-    tg.bot.Send(msg)
+    tg.sendScreen(log, msg)
 }
 
-func (tg *TgBot) handleCallbackQuery(callbackQuery *tgbotapi.CallbackQuery) {
-    chatID := callbackQuery.Message.Chat.ID
-    data := callbackQuery.Data
-    log.Printf("Handling callback query with data: %s for chatID: %d", data, chatID)
-
-    // Create a copy of userData before locking
-    var userData *model.User
-    chat_id := strconv.Itoa(int(chatID))
-
-    tg.stateMutex.Lock()
-    if existing, ok := tg.userData[chatID]; ok {
-        userData = &model.User{
-            Name:           callbackQuery.From.FirstName,
-            UserName:           callbackQuery.From.UserName,
-            Role:           existing.Role,
-            ChatId:        chat_id,
-            Portfolio:      existing.Portfolio,
-            Specialization: existing.Specialization,
-        }
-    } else {
-        userData = &model.User{ChatId: chat_id}
+// finishRegistration saves the answers of the registration flow, or of the
+// executor_role flow for a customer taking on the executor role.
+func (tg *TgBot) finishRegistration(log *slog.Logger, chatID int64, data fsm.Data) {
+    log = log.With(slog.String("handler", "finishRegistration"))
+
+    userData := &model.User{
+        Name:           data[DataName],
+        UserName:       data[DataUserName],
+        ChatId:         strconv.FormatInt(chatID, 10),
+        Role:           model.RoleExecutor,
+        Portfolio:      data[StepPortfolio],
+        Specialization: model.Specialization(data[StepSpecialization]),
+        Language:       string(tg.lang(chatID)),
     }
-    tg.stateMutex.Unlock()
-
-    switch { 
-    case data == "role_customer":
-        userData.Role = "Заказчик"
-        userData.Name = callbackQuery.From.FirstName
-        userData.UserName = callbackQuery.From.UserName
-        if err := tg.service.CreateUser(*userData); err != nil {
-            log.Printf("Error creating customer: %v", err)
-            response := tgbotapi.NewMessage(chatID, "❌ Произошла ошибка при регистрации. Пожалуйста, попробуйте еще раз.")
-            tg.bot.Send(response)
-            return
-        }
-        
-        tg.stateMutex.Lock()
-        tg.userStates[chatID] = StateIdle
-        tg.userData[chatID] = userData
-        tg.stateMutex.Unlock()
-
-        successMsg := fmt.Sprintf(`✅ Регистрация успешно завершена!
-
-🤝 Добро пожаловать в команду заказчиков, %s!
-
-Теперь вы можете:
-- Создавать новые заказы
-- Просматривать отклики исполнителей
-- Управлять своими заказами`, userData.Name)
-
-        response := tgbotapi.NewMessage(chatID, successMsg)
-        tg.bot.Send(response)
-        tg.showUserProfile(chatID, userData)
-
-    case data == "role_executor":
-        userData.Role = "Исполнитель"
-        
-        portfolioMsg := `📸 Для завершения регистрации, пожалуйста, отправьте ссылку на ваше портфолио.
-
-Это может быть:
-- Ссылка на Instagram
-- Ссылка на личный сайт
-- Ссылка на облачное хранилище с работами
-- Любой другой ресурс с вашими работами`
-
-        tg.stateMutex.Lock()
-        tg.userStates[chatID] = StateEnteringPortfolio
-        tg.userData[chatID] = userData
-        tg.stateMutex.Unlock()
-
-        response := tgbotapi.NewMessage(chatID, portfolioMsg)
-        tg.bot.Send(response)
-
-    case data == "specialization_videographer" || data == "specialization_photographer" || 
-         data == "specialization_motion_designer" || data == "specialization_graphic_designer":
-        userData.Specialization = map[string]string{
-            "specialization_videographer":    "Видеооператор",
-            "specialization_photographer":    "Фотограф",
-            "specialization_motion_designer": "Motion Дизайнер",
-            "specialization_graphic_designer": "Графический Дизайнер",
-        }[data]        
-
-        go tg.completeExecutorRegistration(chatID, userData)    
-    case data == "create_order":
-        tg.startOrderCreation(chatID)
-    case strings.HasPrefix(data, "respond_to_order:"):
-        orderID := strings.Split(data, ":")[1]
-        tg.handleOrderResponse(chatID, orderID)
-    case data == "order_spec_videographer" || data == "order_spec_photographer" || data == "order_spec_graph_designer" || data == "order_spec_motion_designer":
-        tg.handleOrderSpecializationSelection(chatID, data)
+    if data[StepRole] == string(model.RoleCustomer) {
+        userData.Role = model.RoleCustomer
     }
-}
-
-func (tg *TgBot) startOrderCreation(chatID int64) {
-    tg.stateMutex.Lock()
-    tg.orderData[chatID] = &model.Order{}
-    tg.userStates[chatID] = StateChoosingOrderSpecialization
-    tg.stateMutex.Unlock()
+    userData.Roles = []model.Role{userData.Role}
+    tg.applyStartLink(log, userData, data)
 
-    specMsg := `🎯 Выберите тип специалиста для вашего заказа:
-
-- Видеооператор - съемка и монтаж видео
-- Фотограф - фотосъемка и обработка фото`
-
-    buttons := [][]tgbotapi.InlineKeyboardButton{
-        {
-            tgbotapi.NewInlineKeyboardButtonData("🎥 Видеооператор", "order_spec_videographer"),
-            tgbotapi.NewInlineKeyboardButtonData("📸 Фотограф", "order_spec_photographer"),
-        },
-        {
-            tgbotapi.NewInlineKeyboardButtonData("🖌️ Motion Дизайнер", "order_spec_motion_designer"),
-            tgbotapi.NewInlineKeyboardButtonData("🎨 Графический Дизайнер", "order_spec_graph_designer"),        
-        },
+    if userData.Role == model.RoleExecutor {
+        tg.completeExecutorRegistration(log, chatID, userData)
+        return
     }
-    keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
-    response := tgbotapi.NewMessage(chatID, specMsg)
-    response.ReplyMarkup = keyboard
-    tg.bot.Send(response)
-}
-
-
-func (tg *TgBot) completeExecutorRegistration(chatID int64, userData *model.User) {
-    log.Printf("Starting executor registration for chatID: %d with data: %+v", chatID, userData)
-    
     err := tg.service.CreateUser(*userData)
-    if err != nil {
-        log.Printf("Error creating executor: %v", err)
-        errorMsg := `❌ Произошла ошибка при регистрации. 
-
-Пожалуйста, попробуйте еще раз или свяжитесь с поддержкой.`
-        response := tgbotapi.NewMessage(chatID, errorMsg)
-        if _, err := tg.bot.Send(response); err != nil {
-            log.Printf("Error sending error message: %v", err)
+    if errors.Is(err, errs.ErrAlreadyExists) {
+        log.Info("customer already registered")
+        if user := tg.currentUser(log, chatID); user != nil {
+            tg.showUserProfile(log, chatID, user)
         }
         return
     }
-    log.Println("User created successfully in database")
-
-    tg.stateMutex.Lock()
-    tg.userStates[chatID] = StateIdle
-    tg.userData[chatID] = userData
-    tg.stateMutex.Unlock()
-    log.Println("User state updated to idle")
+    if err != nil {
+        log.Error("failed to create customer", sl.Err(err))
+        response := tgbotapi.NewMessage(chatID, tg.t(chatID, "registration.error"))
+        tg.send(log, response)
+        return
+    }
 
-    successMsg := fmt.Sprintf(`✅ Регистрация успешно завершена!
+    successMsg := tg.r(chatID, "registration.customer_done", i18n.Vars{"Name": userData.Name})
 
-🎨 Добро пожаловать в команду исполнителей, %s!
+    response := tgbotapi.NewMessage(chatID, successMsg)
+    tg.send(log, response)
+    tg.showUserProfile(log, chatID, userData)
+}
 
-Ваша специализация: %s
+func (tg *TgBot) completeExecutorRegistration(log *slog.Logger, chatID int64, userData *model.User) {
+    log = log.With(slog.String("handler", "completeExecutorRegistration"))
+    log.Debug("registering executor", slog.Any("user", userData))
+
+    _, err := tg.service.GetUserByChatID(userData.ChatId)
+    switch {
+    case err == nil:
+        // A customer taking on the executor role keeps their account.
+        err = tg.service.AddRole(*userData)
+    case errors.Is(err, errs.ErrNotFound):
+        err = tg.service.CreateUser(*userData)
+    }
+    if errors.Is(err, errs.ErrAlreadyExists) {
+        // The same answers arrived twice; the first one registered.
+        log.Info("executor already registered")
+        err = nil
+    }
+    if err != nil {
+        log.Error("failed to create executor", sl.Err(err))
+        errorMsg := tg.t(chatID, "registration.error_support")
+        response := tgbotapi.NewMessage(chatID, errorMsg)
+        tg.send(log, response)
+        return
+    }
 
-Теперь вы можете:
-- Просматривать доступные заказы
-- Откликаться на интересные проекты
-- Общаться с заказчиками`, userData.Name, userData.Specialization)
+    successMsg := tg.r(chatID, "registration.executor_done", i18n.Vars{
+        "Name":           userData.Name,
+        "Specialization": specializationName(tg.lang(chatID), userData.Specialization),
+    })
 
     response := tgbotapi.NewMessage(chatID, successMsg)
-    if _, err := tg.bot.Send(response); err != nil {
-        log.Printf("Error sending confirmation: %v", err)
+    if _, err := tg.send(log, response); err != nil {
         return
     }
-    
-    tg.showUserProfile(chatID, userData)
-    
-    log.Printf("Registration completed successfully for chatID: %d", chatID)
-}
-
-func (tg *TgBot) handlePortfolioInput(message *tgbotapi.Message) {
-    chatID := message.Chat.ID
-    tg.userData[chatID].Portfolio = message.Text
 
-    tg.stateMutex.Lock()
-    tg.userStates[chatID] = StateChoosingSpecialization
-    tg.stateMutex.Unlock()
-
-    specMsg := `🎯 Выберите вашу специализацию:
-
-- Видеооператор - съемка и монтаж видео
-- Фотограф - фотосъемка и обработка фото`
-
-    buttons := [][]tgbotapi.InlineKeyboardButton{
-        {
-            tgbotapi.NewInlineKeyboardButtonData("🎥 Видеооператор", "specialization_videographer"),
-            tgbotapi.NewInlineKeyboardButtonData("📸 Фотограф", "specialization_photographer"),
-        },
-        {
-            tgbotapi.NewInlineKeyboardButtonData("🖌️ Motion Дизайнер", "specialization_motion_designer"),
-            tgbotapi.NewInlineKeyboardButtonData("🎨 Графический Дизайнер", "specialization_graphic_designer"),
-        },
+    if user := tg.currentUser(log, chatID); user != nil {
+        tg.showUserProfile(log, chatID, user)
     }
-
-    keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
-
-    response := tgbotapi.NewMessage(chatID, specMsg)
-    response.ReplyMarkup = keyboard
-    tg.bot.Send(response)
-}
-
-func (tg *TgBot) handleOrderTitleInput(message *tgbotapi.Message) {
-    chatID := message.Chat.ID
     
-    tg.stateMutex.Lock()
-    tg.orderData[chatID].Title = message.Text
-    tg.userStates[chatID] = StateEnteringOrderDescription
-    tg.stateMutex.Unlock()
-
-    msg := `📝 Отлично! Теперь опишите подробности заказа:
-
-Например:
-- Что конкретно нужно сделать
-- Когда планируется съемка
-- Особые пожелания или требования`
-
-    response := tgbotapi.NewMessage(chatID, msg)
-    tg.bot.Send(response)
+    log.Info("executor registered", slog.Any("user", userData))
 }
 
-func (tg *TgBot) handleOrderDescriptionInput(message *tgbotapi.Message) {
-    chatID := message.Chat.ID
-    
-    tg.stateMutex.Lock()
-    tg.orderData[chatID].Description = message.Text
-    tg.userStates[chatID] = StateEnteringOrderLocation
-    tg.stateMutex.Unlock()
-
-    msg := `📍 Укажите место проведения съемки:
-
-Например: "Алматы, парк Горького" или "Студия на Абая 150"`
-
-    response := tgbotapi.NewMessage(chatID, msg)
-    tg.bot.Send(response)
-}
-
-func (tg *TgBot) handleOrderLocationInput(message *tgbotapi.Message) {
-    chatID := message.Chat.ID
-    
-    tg.stateMutex.Lock()
-    order := tg.orderData[chatID]
-    order.Location = message.Text
-    order.CreatedAt = time.Now()
-    user, err := tg.service.GetUserByChatID(strconv.FormatInt(chatID, 10))
-    if err != nil {
-        log.Printf("Error getting user: %v", err)
-        response := tgbotapi.NewMessage(chatID, "❌ Произошла ошибка при создании заказа. Попробуйте еще раз.")
-        tg.bot.Send(response)
+// finishOrder saves the answers of the order flow as a draft.
+func (tg *TgBot) finishOrder(log *slog.Logger, chatID int64, data fsm.Data) {
+    log = log.With(slog.String("handler", "finishOrder"))
 
+    user, ok := tg.authorizeRole(log, chatID, model.RoleCustomer)
+    if !ok {
         return
     }
-    order.User = *user
-    tg.userStates[chatID] = StateIdle
-    defer tg.stateMutex.Unlock()
 
-    // Create order in database
-    createdOrder, err := tg.orderService.CreateOrder(*order)
-    if err != nil {
-        log.Printf("Error creating order: %v", err)
-        response := tgbotapi.NewMessage(chatID, "❌ Произошла ошибка при создании заказа. Попробуйте еще раз.")
-        tg.bot.Send(response)
-        return
+    order := model.Order{
+        User:           *user,
+        Title:          data[StepTitle],
+        Description:    data[StepDescription],
+        Location:       data[StepLocation],
+        Specialization: model.Specialization(data[StepSpecialization]),
+        CreatedAt:      time.Now(),
     }
 
-    // Notify user about successful creation
-    successMsg := fmt.Sprintf(`✅ Заказ успешно создан!
-
-📋 *%s*
-📝 %s
-📍 %s
-
-Мы уведомим исполнителей о вашем заказе.`, order.Title, order.Description, order.Location)
-
-    response := tgbotapi.NewMessage(chatID, successMsg)
-    response.ParseMode = "Markdown"
-    tg.bot.Send(response)
+    // The order stays a draft until the customer checks the preview and
+    // publishes it.
+    tg.saveOrderDraft(log, chatID, order)
+}
 
-    // Notify potential executors
-    tg.notifyExecutors(&createdOrder)
+// reportFanout tells the customer how many executors saw their order.
+func (tg *TgBot) reportFanout(log *slog.Logger, chatID int64, lang i18n.Lang, notified int) {
+    text := i18n.T(lang, "order.no_executors")
+    if notified > 0 {
+        text = i18n.N(lang, "order.executors_notified", notified, notified)
+    }
+    tg.send(log, tgbotapi.NewMessage(chatID, text))
 }
 
-func (tg *TgBot) notifyExecutors(order *model.Order) {
-    executors, err := tg.service.GetUsersBySpecialization(order.Specialization)
-    if err != nil {
-        log.Printf("Error getting executors: %v", err)
-        return
+// notifyExecutors sends the order to every matching executor and returns how
+// many of them received it. Crew orders go to the executors of each slot.
+func (tg *TgBot) notifyExecutors(log *slog.Logger, order *model.Order) int {
+    log = log.With(slog.String("handler", "notifyExecutors"), slog.Int("order_id", order.ID))
+    var executors []model.User
+    for _, spec := range order.Specializations() {
+        users, err := tg.service.GetUsersBySpecialization(spec)
+        if err != nil {
+            log.Error("failed to get executors", sl.Err(err), slog.String("specialization", string(spec)))
+            continue
+        }
+        executors = append(executors, users...)
     }
 
-    log.Println(executors)
+    log.Info("notifying executors", slog.Int("count", len(executors)))
+    tg.metrics.NotificationFanout.Observe(float64(len(executors)))
+    var notified int
     for _, executor := range executors {
         chatID, _ := strconv.ParseInt(executor.ChatId, 10, 64)
-        
-        notificationMsg := fmt.Sprintf(`🆕 Новый заказ!
-
-📋 *%s*
-🎯 Специализация: *%s*
-📝 %s
-📍 %s
-🕒 %s
-
-Заинтересованы в этом заказе?`, 
-            order.Title,
-            order.Specialization,
-            order.Description,
-            order.Location,
-            order.CreatedAt.Format("02.01.2006 15:04"))
-
-        buttons := [][]tgbotapi.InlineKeyboardButton{
-            {
-                tgbotapi.NewInlineKeyboardButtonData("✅ Откликнуться", fmt.Sprintf("respond_to_order:%d", order.ID)),
-            },
-        }
-        keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
-
-        msg := tgbotapi.NewMessage(chatID, notificationMsg)
-        msg.ParseMode = "Markdown"
-        msg.ReplyMarkup = keyboard
-        
-        if _, err := tg.bot.Send(msg); err != nil {
-            log.Printf("Error notifying executor %d: %v", chatID, err)
+        msg := tg.orderOffer(chatID, userLang(executor), order)
+
+        if _, err := tg.send(log.With(slog.Int64("executor_chat_id", chatID)), msg); err == nil {
+            notified++
         }
+        tg.sendToGroups(log, executor, order, nil)
     }
+    return notified
 }
 
-func (tg *TgBot) handleOrderResponse(chatID int64, orderID string) {
-    log.Printf("Starting to handle order response for chatID: %d, orderID: %s", chatID, orderID)
+// orderOffer presents an open order to an executor who may respond to it.
+func (tg *TgBot) orderOffer(chatID int64, lang i18n.Lang, order *model.Order) tgbotapi.MessageConfig {
+    notificationMsg := i18n.R(lang, "order.notification", i18n.Vars{
+        "Title":          order.Title,
+        "Specialization": orderSpecialization(lang, order),
+        "Description":    order.Description,
+        "Location":       order.Location,
+        // When the order went live, not when its draft was started.
+        "CreatedAt":      order.PublishedAt.Format("02.01.2006 15:04"),
+    })
 
-    // Get executor info
-    log.Printf("Fetching executor info for chatID: %d", chatID)
-    executor, err := tg.service.GetUserByChatID(strconv.FormatInt(chatID, 10))
-    if err != nil {
-        log.Printf("Error getting executor with chatID %d: %v", chatID, err)
-        response := tgbotapi.NewMessage(chatID, "❌ Произошла ошибка. Пожалуйста, попробуйте позже.")
-        tg.bot.Send(response)
+    buttons := [][]tgbotapi.InlineKeyboardButton{
+        {
+            tg.button(chatID, i18n.T(lang, "button.respond"), CallbackRespond, strconv.Itoa(order.ID)),
+        },
+    }
+    // Reports are filed by people, not by groups.
+    if !isGroupChatID(chatID) {
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.report"), CallbackReportOrder, strconv.Itoa(order.ID)),
+        ))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, notificationMsg)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    return msg
+}
+
+// handleOrderResponse saves an executor's response, with the note they
+// left in the response flow, and shows them to the customer.
+func (tg *TgBot) handleOrderResponse(log *slog.Logger, chatID int64, orderID, note string) {
+    log = log.With(slog.String("handler", "handleOrderResponse"), slog.String("order_id", orderID))
+
+    // Checked again, since the order may have closed while the executor
+    // was writing the note.
+    executor, order, ok := tg.authorizeResponse(log, chatID, orderID)
+    if !ok {
         return
     }
-    log.Printf("Successfully retrieved executor: %+v", executor)
-
-    // Store response in database
-    log.Printf("Creating order response in database. OrderID: %s, ExecutorID: %s", orderID, executor.ChatId)
-    if err = tg.orderResponseService.CreateOrderResponse(orderID, executor.Id); err != nil {
-        log.Printf("Error creating order response in database: %v", err)
-        response := tgbotapi.NewMessage(chatID, "❌ Произошла ошибка при сохранении отклика. Пожалуйста, попробуйте позже.")
-        tg.bot.Send(response)
+
+    if !tg.saveResponse(log, chatID, executor, &order, note) {
         return
     }
-    log.Printf("Successfully created order response in database")
 
     // Send confirmation to executor
-    log.Printf("Sending confirmation message to executor with chatID: %d", chatID)
-    msg := `✅ Вы успешно откликнулись на заказ!
-
-Заказчик получит уведомление с вашим профилем и свяжется с вами через Telegram.`
+    msg := tg.t(chatID, "response.confirmed")
     response := tgbotapi.NewMessage(chatID, msg)
-    if _, err := tg.bot.Send(response); err != nil {
-        log.Printf("Error sending confirmation to executor: %v", err)
+    if _, err := tg.send(log, response); err != nil {
         return
     }
-    log.Printf("Successfully sent confirmation to executor")
 
-    // Get order details to find customer
-    log.Printf("Fetching order details for orderID: %s", orderID)
-    order, err := tg.orderService.GetOrderByID(orderID)
-    if err != nil {
-        log.Printf("Error getting order details: %v", err)
-        return
+    tg.notifyCustomerOfResponse(log, executor, &order, note)
+}
+
+// saveResponse stores an executor's response, telling chatID when it
+// fails. Owners and managers of a team respond as the team.
+func (tg *TgBot) saveResponse(log *slog.Logger, chatID int64, executor *model.User, order *model.Order, note string) bool {
+    var teamID int
+    if executor.TeamRole.ManagesOrders() {
+        teamID = executor.TeamID
     }
-    log.Printf("Successfully retrieved order: %+v", order)
 
+    if err := tg.orderResponseService.CreateOrderResponse(strconv.Itoa(order.ID), executor.Id, teamID, note); err != nil {
+        log.Error("failed to create order response", sl.Err(err))
+        response := tgbotapi.NewMessage(chatID, tg.t(chatID, "response.save_error"))
+        tg.send(log, response)
+        return false
+    }
+    tg.metrics.ResponsesCreated.Inc()
+    log.Info("order response created", slog.Any("executor", executor))
+    return true
+}
+
+// notifyCustomerOfResponse shows the customer who responded to their order.
+func (tg *TgBot) notifyCustomerOfResponse(log *slog.Logger, executor *model.User, order *model.Order, note string) {
     // Convert customer's chat ID from string to int64
-    log.Printf("Converting customer chatID from string to int64: %s", order.User.ChatId)
     customerChatID, err := strconv.ParseInt(order.User.ChatId, 10, 64)
     if err != nil {
-        log.Printf("Error parsing customer chat ID '%s': %v", order.User.ChatId, err)
+        log.Error("failed to parse customer chat id", sl.Err(err))
         return
     }
-    log.Printf("Successfully converted customer chatID to: %d", customerChatID)
-
-    // Escape the username to handle Telegram Markdown formatting
-    escapedUserName := escapeMarkdown(executor.UserName)
+    log = log.With(slog.Int64("customer_chat_id", customerChatID))
 
     // Prepare and send executor's profile to customer
-    log.Printf("Preparing notification message for customer with chatID: %d", customerChatID)
-    profileText := fmt.Sprintf(`🔔 Новый отклик на ваш заказ *"%s"*, теперь вы можете связаться с исполнителем напрямую!
-
-👤 *Профиль исполнителя:*
-📸 *Роль:* %s
-👤 *Имя:* %s
-🔍 *Username:* @%s
-🎯 *Специализация:* %s`, 
-        escapeMarkdown(order.Title),
-        escapeMarkdown(executor.Role),
-        escapeMarkdown(executor.Name),
-        escapedUserName,
-        escapeMarkdown(executor.Specialization),
-    )
+    customerLang := userLang(order.User)
+    profileText := i18n.R(customerLang, "response.customer_notification", i18n.Vars{
+        "Title":          order.Title,
+        "Role":           roleName(customerLang, model.RoleExecutor),
+        "Name":           executor.Name,
+        "UserName":       executor.UserName,
+        "Specialization": specializationName(customerLang, executor.Specialization),
+        "Note":           note,
+    })
+
+    profileRow := []tgbotapi.InlineKeyboardButton{
+        tgbotapi.NewInlineKeyboardButtonURL(i18n.T(customerLang, "button.executor_portfolio"), executor.Portfolio),
+        tg.button(customerChatID, i18n.T(customerLang, "button.executor_profile"), CallbackExecutorProfile, strconv.Itoa(executor.Id)),
+    }
+    // Owners and managers of a team respond as the team.
+    if executor.TeamRole.ManagesOrders() {
+        if team, err := tg.teamService.GetTeam(executor.TeamID); err != nil {
+            log.Error("failed to get team", sl.Err(err))
+        } else {
+            profileText += "\n\n" + i18n.N(customerLang, "team.responded_as", len(team.Members), team.Name, len(team.Members))
+            profileRow = append(profileRow, tg.button(customerChatID, i18n.T(customerLang, "button.team_profile"), CallbackTeamProfile, strconv.Itoa(team.ID)))
+        }
+    }
 
     buttons := [][]tgbotapi.InlineKeyboardButton{
+        profileRow,
+        {
+            tg.button(customerChatID, i18n.T(customerLang, "button.hire"), CallbackHire, strconv.Itoa(executor.Id), strconv.Itoa(order.ID)),
+        },
         {
-            tgbotapi.NewInlineKeyboardButtonURL("🎨 Портфолио исполнителя", executor.Portfolio),
+            tg.button(customerChatID, i18n.T(customerLang, "button.report"), CallbackReportExecutor, strconv.Itoa(executor.Id), strconv.Itoa(order.ID)),
         },
     }
     keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
     customerMsg := tgbotapi.NewMessage(customerChatID, profileText)
-    customerMsg.ParseMode = "Markdown"
     customerMsg.ReplyMarkup = keyboard
 
-    log.Printf("Sending notification to customer with chatID: %d", customerChatID)
-    if _, err := tg.bot.Send(customerMsg); err != nil {
-        log.Printf("Error sending notification to customer: %v", err)
+    if _, err := tg.send(log, customerMsg); err != nil {
         return
     }
-    log.Printf("Successfully sent notification to customer about new response")
-}
-
-
-func (tg *TgBot) handleOrderSpecializationSelection(chatID int64, data string) {
-    tg.stateMutex.Lock()
-    order, exists := tg.orderData[chatID]
-    if !exists {
-        tg.stateMutex.Unlock()
-        response := tgbotapi.NewMessage(chatID, "❌ Произошла ошибка. Пожалуйста, начните создание заказа заново.")
-        tg.bot.Send(response)
-        return
-    }
-
-    order.Specialization = map[string]string{
-        "order_spec_videographer": "Видеооператор",
-        "order_spec_photographer": "Фотограф",
-        "order_spec_motion_designer": "Motion Дизайнер",
-        "order_spec_graph_designer": "Графический Дизайнер",
-    }[data]
-
-    tg.userStates[chatID] = StateEnteringOrderTitle
-    tg.stateMutex.Unlock()
-
-    msg := `📝 Отлично! Теперь введите название заказа:
-Например: "Свадебная фотосессия" или "Видеосъёмка дня рождения"`
-
-    response := tgbotapi.NewMessage(chatID, msg)
-    tg.bot.Send(response)
-}
-
-func escapeMarkdown(text string) string {
-    // Escape Telegram Markdown special characters
-    replacer := strings.NewReplacer(
-        "_", "\\_",
-        "*", "\\*",
-        "[", "\\[",
-        "]", "\\]",
-        "(", "\\(",
-        ")", "\\)",
-        "`", "\\`",
-    )
-    return replacer.Replace(text)
+    log.Debug("customer notified about new response")
 }
@@ -0,0 +1,272 @@
+package bot
+
+import (
+	"crypto/sha256"
+	"log/slog"
+
+	"github.com/aidosgal/lenshub/internal/callback"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Callback actions. They are kept short: the data of a button has to fit
+// in 64 bytes together with its parameters and signature. Parameters are
+// listed next to each action.
+const (
+    CallbackWizardChoose = "wc" // step, value
+    CallbackWizardBack   = "wb"
+    CallbackWizardCancel = "wx"
+
+    CallbackLanguage   = "lang" // language
+    CallbackSwitchRole = "sr"   // role
+    CallbackAddRole    = "ar"   // role
+
+    CallbackCreateOrder  = "co"
+    CallbackMyOrders     = "mo"
+    CallbackOrderView    = "ov" // order ID
+    CallbackOrderEdit    = "oe" // field, order ID
+    CallbackOrderPublish = "op" // order ID
+    CallbackOrderClose   = "oc" // order ID
+    CallbackOrderRepost  = "or" // order ID
+    CallbackOrderCancel  = "ox" // order ID
+    CallbackOrderCrew    = "ow" // order ID
+    CallbackOrderSlot    = "os" // order ID, specialization, headcount
+
+    CallbackRespond        = "rs" // order ID
+    CallbackReportOrder    = "ro" // order ID
+    CallbackReportExecutor = "re" // executor ID, order ID
+
+    CallbackExecutorProfile = "ep" // executor ID
+    CallbackEditCities      = "ec"
+    CallbackInvite          = "iv" // executor ID, order ID
+    CallbackHire            = "hi" // executor ID, order ID
+    CallbackRate            = "rt" // order ID, stars, executor ID
+
+    CallbackTeam        = "tm" // the user's team, or a new one
+    CallbackTeamProfile = "tp" // team ID
+    CallbackTeamJoin    = "tj" // invite code
+    CallbackTeamMember  = "tb" // user ID
+    CallbackTeamRole    = "tr" // user ID, team role
+    CallbackTeamRemove  = "tx" // user ID
+    CallbackTeamLeave   = "tl"
+    CallbackTeamDisband = "td"
+    CallbackTeamAssign  = "ta" // order ID, user ID
+
+    CallbackModerateApprove = "ma" // order ID
+    CallbackModerateReject  = "mr" // order ID
+    CallbackReportBan       = "rb" // report ID
+    CallbackReportDismiss   = "rd" // report ID
+)
+
+// callbackCall is a verified button press.
+type callbackCall struct {
+    chatID  int64
+    user    *model.User
+    payload callback.Payload
+}
+
+type callbackRoute struct {
+    // admin routes are ignored for everyone but admins.
+    admin  bool
+    handle func(log *slog.Logger, call callbackCall)
+}
+
+// callbackSecret derives the key that signs callback data from the bot
+// token, which is secret already and stable across restarts. A new token
+// retires all buttons sent before.
+func callbackSecret(token string) []byte {
+    sum := sha256.Sum256([]byte("lenshub callback data\x00" + token))
+    return sum[:]
+}
+
+// button builds an inline button whose data is signed for the chat it is
+// sent to.
+func (tg *TgBot) button(chatID int64, label, action string, params ...string) tgbotapi.InlineKeyboardButton {
+    data, err := tg.callbacks.Encode(chatID, action, params...)
+    if err != nil {
+        // Parameters are IDs and enum values, so this is a programming
+        // error. The button is still sent and reported as expired when
+        // pressed.
+        tg.log.Error("failed to encode callback data", sl.Err(err))
+        data = action
+    }
+    return tgbotapi.NewInlineKeyboardButtonData(label, data)
+}
+
+func (tg *TgBot) callbackRoutes() map[string]callbackRoute {
+    return map[string]callbackRoute{
+        CallbackWizardChoose: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleFlowChoice(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackWizardBack: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleWizardBack(log, c.chatID, c.user)
+        }},
+        CallbackWizardCancel: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleCancel(log, c.chatID, c.user)
+        }},
+        CallbackLanguage: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleLanguageSelection(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackSwitchRole: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleRoleSwitch(log, c.chatID, c.user, c.payload.Param(0))
+        }},
+        CallbackAddRole: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleAddRole(log, c.chatID, c.user, c.payload.Param(0))
+        }},
+        CallbackCreateOrder: {handle: func(log *slog.Logger, c callbackCall) {
+            if _, ok := tg.authorizeRole(log, c.chatID, model.RoleCustomer); ok {
+                tg.startFlow(log, c.chatID, FlowOrder, nil)
+            }
+        }},
+        CallbackMyOrders: {handle: func(log *slog.Logger, c callbackCall) {
+            if user, ok := tg.authorizeRole(log, c.chatID, model.RoleCustomer); ok {
+                tg.handleMyOrders(log, c.chatID, user)
+            }
+        }},
+        CallbackOrderView: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderView(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackOrderEdit: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderEdit(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackOrderPublish: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderPublish(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackOrderClose: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderClose(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackOrderCancel: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderCancel(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackOrderRepost: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderRepost(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackOrderCrew: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderCrew(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackOrderSlot: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleOrderSlot(log, c.chatID, c.payload.Param(0), c.payload.Param(1), c.payload.Param(2))
+        }},
+        CallbackRespond: {handle: func(log *slog.Logger, c callbackCall) {
+            // The response is saved once the executor adds a note or skips it.
+            if _, _, ok := tg.authorizeResponse(log, c.chatID, c.payload.Param(0)); ok {
+                tg.startFlow(log, c.chatID, FlowResponse, fsm.Data{DataOrderID: c.payload.Param(0)})
+            }
+        }},
+        CallbackReportOrder: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleReportOrder(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackReportExecutor: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleReportExecutor(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackExecutorProfile: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.showExecutorProfile(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackEditCities: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleEditCities(log, c.chatID)
+        }},
+        CallbackInvite: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleInvite(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackHire: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleHire(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackRate: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleRate(log, c.chatID, c.payload.Param(0), c.payload.Param(1), c.payload.Param(2))
+        }},
+        CallbackTeam: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeam(log, c.chatID)
+        }},
+        CallbackTeamProfile: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.showTeamProfile(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackTeamJoin: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamJoin(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackTeamMember: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamMember(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackTeamRole: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamRole(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackTeamRemove: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamRemove(log, c.chatID, c.payload.Param(0))
+        }},
+        CallbackTeamLeave: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamLeave(log, c.chatID)
+        }},
+        CallbackTeamDisband: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamDisband(log, c.chatID)
+        }},
+        CallbackTeamAssign: {handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleTeamAssign(log, c.chatID, c.payload.Param(0), c.payload.Param(1))
+        }},
+        CallbackModerateApprove: {admin: true, handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleModerationDecision(log, c.chatID, c.payload.Param(0), true)
+        }},
+        CallbackModerateReject: {admin: true, handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleModerationDecision(log, c.chatID, c.payload.Param(0), false)
+        }},
+        CallbackReportBan: {admin: true, handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleReportResolution(log, c.chatID, c.payload.Param(0), true)
+        }},
+        CallbackReportDismiss: {admin: true, handle: func(log *slog.Logger, c callbackCall) {
+            tg.handleReportResolution(log, c.chatID, c.payload.Param(0), false)
+        }},
+    }
+}
+
+func (tg *TgBot) handleCallbackQuery(log *slog.Logger, callbackQuery *tgbotapi.CallbackQuery) {
+    log = log.With(slog.String("handler", "handleCallbackQuery"))
+    // Presses under inline messages and under messages too old to reach
+    // come without the message. The bot sends no such buttons.
+    if callbackQuery.Message == nil {
+        log.Warn("callback query without a message")
+        tg.answerCallback(log, callbackQuery.ID, tg.t(callbackQuery.From.ID, "callback.expired"))
+        return
+    }
+    chatID := callbackQuery.Message.Chat.ID
+
+    payload, err := tg.callbacks.Decode(chatID, callbackQuery.Data)
+    if err != nil {
+        log.Warn("rejected callback data", sl.Err(err))
+        tg.answerCallback(log, callbackQuery.ID, tg.t(chatID, "callback.expired"))
+        return
+    }
+    defer tg.answerCallback(log, callbackQuery.ID, "")
+    log = log.With(slog.String("action", payload.Action))
+
+    if isGroupChat(callbackQuery.Message.Chat) {
+        tg.handleGroupCallback(log, callbackQuery, payload)
+        return
+    }
+
+    user := tg.currentUser(log, chatID)
+    tg.rememberLang(chatID, callbackQuery.From, user)
+    if tg.rejectBanned(log, chatID, user) {
+        return
+    }
+
+    route, ok := tg.routeFor(log, chatID, payload.Action)
+    if !ok {
+        return
+    }
+    route.handle(log, callbackCall{chatID: chatID, user: user, payload: payload})
+}
+
+// routeFor finds the handler of a verified button press. Unknown actions
+// have none, and neither have admin actions pressed by anyone else.
+func (tg *TgBot) routeFor(log *slog.Logger, chatID int64, action string) (callbackRoute, bool) {
+    route, ok := tg.routes[action]
+    if !ok {
+        log.Warn("unknown callback action")
+        return callbackRoute{}, false
+    }
+    if route.admin && !tg.isAdmin(chatID) {
+        log.Warn("admin callback from a non-admin")
+        return callbackRoute{}, false
+    }
+    return route, true
+}
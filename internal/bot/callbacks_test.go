@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aidosgal/lenshub/internal/callback"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/metrics"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+    testAdmin = 1001
+    testUser  = 2002
+)
+
+// fakeTelegram answers every Bot API request with success and keeps the
+// names of the called methods.
+type fakeTelegram struct {
+    methods []string
+}
+
+func (f *fakeTelegram) Do(req *http.Request) (*http.Response, error) {
+    f.methods = append(f.methods, path.Base(req.URL.Path))
+    return &http.Response{
+        StatusCode: http.StatusOK,
+        Header:     http.Header{},
+        Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":true}`)),
+    }, nil
+}
+
+// newTestBot builds a bot without services, enough for the paths that
+// stop before calling one.
+func newTestBot() (*TgBot, *fakeTelegram) {
+    api := &fakeTelegram{}
+    tg := &TgBot{
+        admins:    map[int64]struct{}{testAdmin: {}},
+        flows:     newFlows(),
+        callbacks: callback.NewCodec([]byte("test secret")),
+        screens:   make(map[int64]int),
+        latest:    make(map[int64]int),
+        langs:     make(map[int64]i18n.Lang),
+        metrics:   metrics.New(),
+        log:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+    }
+    tg.bot.Token = "test"
+    tg.bot.Client = api
+    tg.bot.SetAPIEndpoint(tgbotapi.APIEndpoint)
+    tg.routes = tg.callbackRoutes()
+    return tg, api
+}
+
+func TestEveryActionIsRouted(t *testing.T) {
+    actions := []string{
+        CallbackWizardChoose, CallbackWizardBack, CallbackWizardCancel,
+        CallbackLanguage, CallbackSwitchRole, CallbackAddRole,
+        CallbackCreateOrder, CallbackMyOrders, CallbackOrderView, CallbackOrderEdit, CallbackOrderPublish,
+        CallbackOrderClose, CallbackOrderRepost, CallbackOrderCancel, CallbackOrderCrew, CallbackOrderSlot,
+        CallbackRespond, CallbackReportOrder, CallbackReportExecutor,
+        CallbackExecutorProfile, CallbackEditCities, CallbackInvite, CallbackHire, CallbackRate,
+        CallbackTeam, CallbackTeamProfile, CallbackTeamJoin, CallbackTeamMember, CallbackTeamRole,
+        CallbackTeamRemove, CallbackTeamLeave, CallbackTeamDisband, CallbackTeamAssign,
+        CallbackModerateApprove, CallbackModerateReject, CallbackReportBan, CallbackReportDismiss,
+    }
+    tg, _ := newTestBot()
+
+    for _, action := range actions {
+        if route, ok := tg.routes[action]; !ok || route.handle == nil {
+            t.Errorf("action %q has no handler", action)
+        }
+    }
+    if len(tg.routes) != len(actions) {
+        t.Errorf("%d routes for %d actions", len(tg.routes), len(actions))
+    }
+}
+
+func TestOnlyModerationActionsAreAdmin(t *testing.T) {
+    tg, _ := newTestBot()
+    got := make(map[string]bool)
+    for action, route := range tg.routes {
+        if route.admin {
+            got[action] = true
+        }
+    }
+
+    want := map[string]bool{
+        CallbackModerateApprove: true, CallbackModerateReject: true,
+        CallbackReportBan: true, CallbackReportDismiss: true,
+    }
+    if !reflect.DeepEqual(got, want) {
+        t.Errorf("admin actions = %v, want %v", got, want)
+    }
+}
+
+func TestRouteFor(t *testing.T) {
+    tg, _ := newTestBot()
+    tests := []struct {
+        name   string
+        chatID int64
+        action string
+        ok     bool
+    }{
+        {"user action", testUser, CallbackMyOrders, true},
+        {"user action by an admin", testAdmin, CallbackMyOrders, true},
+        {"admin action", testAdmin, CallbackModerateApprove, true},
+        {"admin action by a user", testUser, CallbackModerateApprove, false},
+        {"admin ban by a user", testUser, CallbackReportBan, false},
+        {"unknown action", testAdmin, "zz", false},
+    }
+
+    for _, tt := range tests {
+        if _, ok := tg.routeFor(tg.log, tt.chatID, tt.action); ok != tt.ok {
+            t.Errorf("%s: routed = %v, want %v", tt.name, ok, tt.ok)
+        }
+    }
+}
+
+func TestCallbackWithoutMessage(t *testing.T) {
+    tg, api := newTestBot()
+    // An inline message's button: no message, only its inline ID.
+    tg.handleCallbackQuery(tg.log, &tgbotapi.CallbackQuery{
+        ID:              "1",
+        From:            &tgbotapi.User{ID: testUser},
+        InlineMessageID: "inline",
+        Data:            "1:mo:sig",
+    })
+
+    if want := []string{"answerCallbackQuery"}; !reflect.DeepEqual(api.methods, want) {
+        t.Errorf("requests = %v, want %v", api.methods, want)
+    }
+}
+
+func TestCallbackWithForeignData(t *testing.T) {
+    tg, api := newTestBot()
+    // Signed for another chat, e.g. copied from a forwarded message.
+    data, err := tg.callbacks.Encode(testAdmin, CallbackModerateApprove, "42")
+    if err != nil {
+        t.Fatal(err)
+    }
+    tg.handleCallbackQuery(tg.log, &tgbotapi.CallbackQuery{
+        ID:      "1",
+        From:    &tgbotapi.User{ID: testUser},
+        Message: &tgbotapi.Message{MessageID: 7, Chat: &tgbotapi.Chat{ID: testUser, Type: "private"}},
+        Data:    data,
+    })
+
+    if want := []string{"answerCallbackQuery"}; !reflect.DeepEqual(api.methods, want) {
+        t.Errorf("requests = %v, want %v", api.methods, want)
+    }
+}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/config"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// channelMatches tells whether an order belongs in a channel.
+func channelMatches(channel config.ChannelConfig, order *model.Order) bool {
+    if len(channel.Specializations) > 0 {
+        var found bool
+        for _, spec := range channel.Specializations {
+            if order.Headcount(spec) > 0 {
+                found = true
+                break
+            }
+        }
+        if !found {
+            return false
+        }
+    }
+
+    if len(channel.Cities) == 0 {
+        return true
+    }
+    location := strings.ToLower(order.Location)
+    for _, city := range channel.Cities {
+        if strings.Contains(location, strings.ToLower(city)) {
+            return true
+        }
+    }
+    return false
+}
+
+// channelPost renders an order as a channel post. Open orders get a button
+// taking executors to the bot to respond, closed ones are marked as such.
+func (tg *TgBot) channelPost(lang i18n.Lang, order *model.Order) (string, *tgbotapi.InlineKeyboardMarkup) {
+    text := i18n.R(lang, "order.channel_post", i18n.Vars{
+        "Title":          order.Title,
+        "Specialization": orderSpecialization(lang, order),
+        "Description":    order.Description,
+        "Location":       order.Location,
+    })
+    if order.Status != model.OrderStatusOpen {
+        return i18n.T(lang, "channel.order_closed") + "\n\n" + text, nil
+    }
+
+    keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+        tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "button.respond"), tg.startLinkURL(LinkOrder, strconv.Itoa(order.ID))),
+    ))
+    return text, &keyboard
+}
+
+// publishToChannels posts a newly opened order to every channel it matches.
+func (tg *TgBot) publishToChannels(log *slog.Logger, order *model.Order) {
+    log = log.With(slog.String("handler", "publishToChannels"), slog.Int("order_id", order.ID))
+
+    for _, channel := range tg.channels {
+        if !channelMatches(channel, order) {
+            continue
+        }
+        channelLog := log.With(slog.Int64("channel_id", channel.ChatID))
+
+        text, keyboard := tg.channelPost(i18n.Detect(channel.Language), order)
+        msg := tgbotapi.NewMessage(channel.ChatID, text)
+        msg.ReplyMarkup = keyboard
+        sent, err := tg.send(channelLog, msg)
+        if err != nil {
+            continue
+        }
+
+        post := model.ChannelPost{OrderID: order.ID, ChatID: channel.ChatID, MessageID: sent.MessageID}
+        if err := tg.channelService.SavePost(post); err != nil {
+            channelLog.Error("failed to save channel post", sl.Err(err))
+            continue
+        }
+        channelLog.Info("order posted to channel")
+    }
+}
+
+// updateChannelPosts brings the channel posts of an order in line with its
+// details and status, e.g. after it was edited or closed.
+func (tg *TgBot) updateChannelPosts(log *slog.Logger, order *model.Order) {
+    log = log.With(slog.String("handler", "updateChannelPosts"), slog.Int("order_id", order.ID))
+
+    posts, err := tg.channelService.GetPosts(order.ID)
+    if err != nil {
+        log.Error("failed to get channel posts", sl.Err(err))
+        return
+    }
+
+    for _, post := range posts {
+        text, keyboard := tg.channelPost(tg.channelLang(post.ChatID), order)
+        edit := tgbotapi.NewEditMessageText(post.ChatID, post.MessageID, text)
+        edit.ReplyMarkup = keyboard
+        tg.edit(log.With(slog.Int64("channel_id", post.ChatID)), edit)
+    }
+}
+
+// deleteChannelPosts removes the channel posts of a cancelled order.
+func (tg *TgBot) deleteChannelPosts(log *slog.Logger, order *model.Order) {
+    log = log.With(slog.String("handler", "deleteChannelPosts"), slog.Int("order_id", order.ID))
+
+    posts, err := tg.channelService.GetPosts(order.ID)
+    if err != nil {
+        log.Error("failed to get channel posts", sl.Err(err))
+        return
+    }
+
+    for _, post := range posts {
+        channelLog := log.With(slog.Int64("channel_id", post.ChatID))
+        if _, err := tg.bot.Request(tgbotapi.NewDeleteMessage(post.ChatID, post.MessageID)); err != nil {
+            tg.metrics.TelegramSendErrors.Inc()
+            channelLog.Warn("failed to delete channel post", sl.Err(err))
+            continue
+        }
+        if err := tg.channelService.DeletePost(post); err != nil {
+            channelLog.Error("failed to forget channel post", sl.Err(err))
+        }
+    }
+}
+
+// channelLang is the language posts in a channel are written in. Channels
+// dropped from the config keep the default.
+func (tg *TgBot) channelLang(chatID int64) i18n.Lang {
+    for _, channel := range tg.channels {
+        if channel.ChatID == chatID {
+            return i18n.Detect(channel.Language)
+        }
+    }
+    return i18n.Default
+}
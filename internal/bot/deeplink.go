@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Start links open the bot with a payload: t.me/<bot>?start=<kind>_<value>.
+// Telegram hands the payload over as the argument of /start. The value each
+// kind carries is listed next to it.
+const (
+    LinkOrder    = "order" // order ID
+    LinkReferral = "ref"   // referral code of the inviting user
+    LinkExecutor = "exec"  // executor's user ID
+    LinkTeam     = "team"  // invite code of the team
+)
+
+// startPayloadPattern is what Telegram allows in a start payload.
+var startPayloadPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// inviteCodePattern matches the invite codes of teams, which are base32.
+var inviteCodePattern = regexp.MustCompile(`^[a-z2-7]{8,16}$`)
+
+type startLink struct {
+    kind  string
+    value string
+}
+
+// String returns the payload of the link.
+func (l startLink) String() string {
+    return l.kind + "_" + l.value
+}
+
+// parseStartLink reads the payload of /start. Payloads of unknown kinds or
+// with malformed values are not ok and open the bot as a plain /start does.
+func parseStartLink(payload string) (startLink, bool) {
+    if !startPayloadPattern.MatchString(payload) {
+        return startLink{}, false
+    }
+    kind, value, _ := strings.Cut(payload, "_")
+
+    // Values are normalized, so the same link is always tracked under the
+    // same payload.
+    switch kind {
+    case LinkOrder, LinkExecutor:
+        id, err := strconv.Atoi(value)
+        if err != nil || id <= 0 {
+            return startLink{}, false
+        }
+        value = strconv.Itoa(id)
+    case LinkReferral:
+        id, ok := parseReferralCode(value)
+        if !ok {
+            return startLink{}, false
+        }
+        value = referralCode(id)
+    case LinkTeam:
+        value = strings.ToLower(value)
+        if !inviteCodePattern.MatchString(value) {
+            return startLink{}, false
+        }
+    default:
+        return startLink{}, false
+    }
+    return startLink{kind: kind, value: value}, true
+}
+
+// referralCode is the code in a user's invite link. It only has to be
+// short; there is nothing to gain from guessing someone else's.
+func referralCode(userID int) string {
+    return strconv.FormatInt(int64(userID), 36)
+}
+
+func parseReferralCode(code string) (int, bool) {
+    id, err := strconv.ParseInt(code, 36, 32)
+    if err != nil || id <= 0 {
+        return 0, false
+    }
+    return int(id), true
+}
+
+// startLinkURL builds a link that opens the bot with the given payload.
+func (tg *TgBot) startLinkURL(kind, value string) string {
+    return fmt.Sprintf("https://t.me/%s?start=%s", tg.bot.Self.UserName, startLink{kind: kind, value: value})
+}
+
+// inviteButton shares the user's referral link through Telegram's share
+// dialog.
+func (tg *TgBot) inviteButton(chatID int64, user *model.User) tgbotapi.InlineKeyboardButton {
+    share := url.Values{
+        "url":  {tg.startLinkURL(LinkReferral, referralCode(user.Id))},
+        "text": {tg.t(chatID, "invite.text")},
+    }
+    return tgbotapi.NewInlineKeyboardButtonURL(tg.t(chatID, "button.invite"), "https://t.me/share/url?"+share.Encode())
+}
+
+// trackStartLink records that a chat opened the bot with a link.
+func (tg *TgBot) trackStartLink(log *slog.Logger, chatID int64, link startLink, registered bool) {
+    err := tg.linkService.RecordVisit(strconv.FormatInt(chatID, 10), link.String(), link.kind, registered)
+    if err != nil {
+        log.Error("failed to record start link visit", sl.Err(err))
+    }
+}
+
+// applyStartLink credits a new user's registration to the link that
+// brought them in, from the registration flow's data.
+func (tg *TgBot) applyStartLink(log *slog.Logger, user *model.User, data fsm.Data) {
+    link, ok := parseStartLink(data[DataStartLink])
+    if !ok {
+        return
+    }
+    user.Source = link.String()
+
+    if link.kind != LinkReferral {
+        return
+    }
+    referrerID, _ := parseReferralCode(link.value)
+    referrer, err := tg.service.GetUserByID(referrerID)
+    if err != nil {
+        if !errors.Is(err, errs.ErrNotFound) {
+            log.Error("failed to get referrer", sl.Err(err))
+        }
+        return
+    }
+    if referrer.Banned {
+        return
+    }
+    user.ReferredBy = referrer.Id
+}
+
+// followStartLink opens what the link a user registered through points
+// to, once the registration is done.
+func (tg *TgBot) followStartLink(log *slog.Logger, chatID int64, data fsm.Data) {
+    link, ok := parseStartLink(data[DataStartLink])
+    if !ok || link.kind == LinkReferral {
+        return
+    }
+    if user := tg.currentUser(log, chatID); user != nil {
+        tg.openStartLink(log, chatID, user, link)
+    }
+}
+
+// openStartLink shows a registered user what a link points to.
+func (tg *TgBot) openStartLink(log *slog.Logger, chatID int64, user *model.User, link startLink) {
+    log = log.With(slog.String("start_link", link.String()))
+
+    switch link.kind {
+    case LinkOrder:
+        tg.openOrderLink(log, chatID, user, link.value)
+    case LinkExecutor:
+        tg.showExecutorProfile(log, chatID, link.value)
+    case LinkTeam:
+        tg.showTeamInvite(log, chatID, link.value)
+    default:
+        tg.showUserProfile(log, chatID, user)
+    }
+}
+
+// openOrderLink shows a shared order: its card to the owner, and the offer
+// executors get in notifications to those who may respond.
+func (tg *TgBot) openOrderLink(log *slog.Logger, chatID int64, user *model.User, orderID string) {
+    order, err := tg.orderService.GetOrderByID(orderID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    if order.User.Id == user.Id {
+        tg.sendOrderCard(log, chatID, &order)
+        return
+    }
+
+    if _, _, ok := tg.authorizeResponse(log, chatID, orderID); !ok {
+        return
+    }
+    tg.send(log, tg.orderOffer(chatID, tg.lang(chatID), &order))
+}
@@ -0,0 +1,203 @@
+package bot
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+// Conversations the bot runs through the fsm package. Each flow is finished
+// in finishFlow.
+const (
+    FlowRegistration = "registration"
+    FlowExecutorRole = "executor_role"
+    FlowOrder        = "order"
+    FlowOrderEdit    = "order_edit"
+    FlowResponse     = "response"
+    FlowCities       = "cities"
+    FlowTeam         = "team"
+)
+
+// Step names double as the keys of the answers in fsm.Data.
+const (
+    StepRole           = "role"
+    StepPortfolio      = "portfolio"
+    StepSpecialization = "specialization"
+    StepTitle          = "title"
+    StepDescription    = "description"
+    StepLocation       = "location"
+    StepNote           = "note"
+    StepCities         = "cities"
+    StepTeamName       = "team_name"
+)
+
+// Keys of the data flows are started with.
+const (
+    DataName      = "name"
+    DataUserName  = "user_name"
+    DataOrderID   = "order_id"
+    DataStartLink = "start_link" // payload of the link a new user came with
+)
+
+// maxNoteLength is the size of responses.message.
+const maxNoteLength = 1000
+
+// maxPortfolioLength is the size of user_roles.portfolio_url.
+const maxPortfolioLength = 255
+
+var portfolioStep = fsm.Step{
+    Name:     StepPortfolio,
+    Prompt:   fsm.Prompt{Key: "registration.portfolio_prompt"},
+    Validate: validatePortfolio,
+    Next:     fsm.Goto(StepSpecialization),
+}
+
+var executorSpecializationStep = fsm.Step{
+    Name:   StepSpecialization,
+    Prompt: fsm.Prompt{Key: "registration.specialization_prompt", Buttons: specializationButtons()},
+}
+
+var registrationFlow = &fsm.Flow{
+    Name: FlowRegistration,
+    Steps: []fsm.Step{
+        {
+            Name: StepRole,
+            Prompt: fsm.Prompt{Key: "welcome", Buttons: [][]fsm.Button{{
+                {Key: "button.role_customer", Value: string(model.RoleCustomer)},
+                {Key: "button.role_executor", Value: string(model.RoleExecutor)},
+            }}},
+            Next: func(data fsm.Data) string {
+                if data[StepRole] == string(model.RoleCustomer) {
+                    return fsm.Finish
+                }
+                return StepPortfolio
+            },
+        },
+        portfolioStep,
+        executorSpecializationStep,
+    },
+}
+
+// executorRoleFlow lets a registered customer become an executor too.
+var executorRoleFlow = &fsm.Flow{
+    Name:  FlowExecutorRole,
+    Steps: []fsm.Step{portfolioStep, executorSpecializationStep},
+}
+
+var orderFlow = &fsm.Flow{
+    Name: FlowOrder,
+    Steps: []fsm.Step{
+        {
+            Name:   StepSpecialization,
+            Prompt: fsm.Prompt{Key: "order.specialization_prompt", Buttons: specializationButtons()},
+            Next:   fsm.Goto(StepTitle),
+        },
+        {
+            Name:   StepTitle,
+            Prompt:   fsm.Prompt{Key: "order.title_prompt"},
+            Validate: orderTitleRule.validate,
+            Next:     fsm.Goto(StepDescription),
+        },
+        {
+            Name:   StepDescription,
+            Prompt:   fsm.Prompt{Key: "order.description_prompt"},
+            Validate: orderDescriptionRule.validate,
+            Next:     fsm.Goto(StepLocation),
+        },
+        {
+            Name:     StepLocation,
+            Prompt:   fsm.Prompt{Key: "order.location_prompt"},
+            Validate: orderLocationRule.validate,
+        },
+    },
+}
+
+// orderEditFlow changes one field of an order; it is entered at the step of
+// that field with DataOrderID set.
+var orderEditFlow = &fsm.Flow{
+    Name: FlowOrderEdit,
+    Steps: []fsm.Step{
+        {Name: StepTitle, Prompt: fsm.Prompt{Key: "order.title_prompt"}, Validate: orderTitleRule.validate},
+        {Name: StepDescription, Prompt: fsm.Prompt{Key: "order.description_prompt"}, Validate: orderDescriptionRule.validate},
+        {Name: StepLocation, Prompt: fsm.Prompt{Key: "order.location_prompt"}, Validate: orderLocationRule.validate},
+    },
+}
+
+// responseFlow lets an executor add a note for the customer to their
+// response; it is started with DataOrderID set.
+var responseFlow = &fsm.Flow{
+    Name: FlowResponse,
+    Steps: []fsm.Step{
+        {
+            Name: StepNote,
+            Prompt: fsm.Prompt{Key: "response.note_prompt", Buttons: [][]fsm.Button{{
+                {Key: "button.skip", Value: ""},
+            }}},
+            FreeText: true,
+            Validate: validateNote,
+        },
+    },
+}
+
+// citiesFlow changes the cities on an executor's public profile.
+var citiesFlow = &fsm.Flow{
+    Name: FlowCities,
+    Steps: []fsm.Step{
+        {Name: StepCities, Prompt: fsm.Prompt{Key: "profile.cities_prompt"}, Validate: validateCities},
+    },
+}
+
+// teamFlow names the team an executor creates.
+var teamFlow = &fsm.Flow{
+    Name: FlowTeam,
+    Steps: []fsm.Step{
+        {Name: StepTeamName, Prompt: fsm.Prompt{Key: "team.name_prompt"}, Validate: teamNameRule.validate},
+    },
+}
+
+func newFlows() *fsm.Machine {
+    return fsm.New(registrationFlow, executorRoleFlow, orderFlow, orderEditFlow, responseFlow, citiesFlow, teamFlow)
+}
+
+// specializationButtons offers every specialization, two per row.
+func specializationButtons() [][]fsm.Button {
+    var rows [][]fsm.Button
+    for i, spec := range model.Specializations {
+        button := fsm.Button{Key: "button.spec_" + string(spec), Value: string(spec)}
+        if i%2 == 0 {
+            rows = append(rows, []fsm.Button{button})
+        } else {
+            rows[len(rows)-1] = append(rows[len(rows)-1], button)
+        }
+    }
+    return rows
+}
+
+// validatePortfolio accepts web links, adding the scheme when it is
+// missing, since the portfolio is shown as a URL button.
+func validatePortfolio(text string) (string, error) {
+    if !strings.Contains(text, "://") {
+        text = "https://" + text
+    }
+
+    u, err := url.Parse(text)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || !strings.Contains(u.Host, ".") {
+        return "", &fsm.Reject{Key: "registration.portfolio_invalid"}
+    }
+    // The link is measured as it is stored, with the scheme added.
+    portfolio := u.String()
+    if length := utf8.RuneCountInString(portfolio); length > maxPortfolioLength {
+        return "", &fsm.Reject{Key: "input.too_long", Args: []any{maxPortfolioLength, length}}
+    }
+    return portfolio, nil
+}
+
+func validateNote(text string) (string, error) {
+    if utf8.RuneCountInString(text) > maxNoteLength {
+        return "", &fsm.Reject{Key: "response.note_too_long", Args: []any{maxNoteLength}}
+    }
+    return text, nil
+}
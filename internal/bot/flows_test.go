@@ -0,0 +1,223 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/fsm/fsmtest"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+func TestRegistrationFlowCustomer(t *testing.T) {
+    fsmtest.New(t, registrationFlow).
+        Start(FlowRegistration, fsm.Data{DataName: "Aida", DataUserName: "aida"}).
+        ExpectPrompt("welcome").
+        Choose(string(model.RoleCustomer)).
+        ExpectDone(fsm.Data{StepRole: string(model.RoleCustomer), DataName: "Aida", DataUserName: "aida"})
+}
+
+func TestRegistrationFlowExecutor(t *testing.T) {
+    fsmtest.New(t, registrationFlow).
+        Start(FlowRegistration, nil).
+        Choose(string(model.RoleExecutor)).
+        ExpectPrompt("registration.portfolio_prompt").
+        Text("my portfolio").
+        ExpectRejected("registration.portfolio_invalid").
+        Text("behance.net/aida").
+        ExpectPrompt("registration.specialization_prompt").
+        Text("photographer").
+        ExpectRejected(fsm.RejectUseButtons).
+        Choose(string(model.SpecializationPhotographer)).
+        ExpectDone(fsm.Data{
+            StepRole:           string(model.RoleExecutor),
+            StepPortfolio:      "https://behance.net/aida",
+            StepSpecialization: string(model.SpecializationPhotographer),
+        })
+}
+
+func TestRegistrationFlowPortfolioLength(t *testing.T) {
+    // "https://" is added to links without a scheme and counts too.
+    path := strings.Repeat("a", maxPortfolioLength-len("https://behance.net/"))
+    fsmtest.New(t, registrationFlow).
+        StartAt(FlowRegistration, StepPortfolio, nil).
+        Text("behance.net/" + path + "a").
+        ExpectRejected("input.too_long").
+        Text("behance.net/" + path).
+        ExpectStep(StepSpecialization)
+}
+
+func TestRegistrationFlowBack(t *testing.T) {
+    fsmtest.New(t, registrationFlow).
+        Start(FlowRegistration, nil).
+        Choose(string(model.RoleExecutor)).
+        Text("behance.net/aida").
+        ExpectStep(StepSpecialization).
+        Back().
+        ExpectPrompt("registration.portfolio_prompt").
+        Back().
+        ExpectPrompt("welcome").
+        Back().
+        ExpectCancelled()
+}
+
+func TestRegistrationFlowCancel(t *testing.T) {
+    fsmtest.New(t, registrationFlow).
+        Start(FlowRegistration, nil).
+        Choose(string(model.RoleExecutor)).
+        Cancel().
+        ExpectCancelled().
+        Text("behance.net/aida").
+        ExpectIgnored()
+}
+
+func TestRegistrationFlowRejectsNonText(t *testing.T) {
+    fsmtest.New(t, registrationFlow).
+        Start(FlowRegistration, nil).
+        Text("").
+        ExpectRejected(fsm.RejectUseButtons).
+        Choose(string(model.RoleExecutor)).
+        Text("").
+        ExpectRejected(fsm.RejectTextRequired).
+        ExpectPrompt("registration.portfolio_prompt")
+}
+
+func TestOrderFlow(t *testing.T) {
+    fsmtest.New(t, orderFlow).
+        Start(FlowOrder, nil).
+        ExpectPrompt("order.specialization_prompt").
+        Choose(string(model.SpecializationVideographer)).
+        ExpectPrompt("order.title_prompt").
+        Text("Wedding").
+        ExpectPrompt("order.description_prompt").
+        Text("Two cameras, five hours").
+        ExpectPrompt("order.location_prompt").
+        Text("Almaty, maps.google.com/?q=Almaty").
+        ExpectDone(fsm.Data{
+            StepSpecialization: string(model.SpecializationVideographer),
+            StepTitle:          "Wedding",
+            StepDescription:    "Two cameras, five hours",
+            StepLocation:       "Almaty, maps.google.com/?q=Almaty",
+        })
+}
+
+func TestOrderFlowValidation(t *testing.T) {
+    fsmtest.New(t, orderFlow).
+        StartAt(FlowOrder, StepTitle, nil).
+        Text("ab").
+        ExpectRejected("input.too_short").
+        Text(strings.Repeat("a", maxOrderTitleLength+1)).
+        ExpectRejected("input.too_long").
+        Text("Call me at t.me/someone").
+        ExpectRejected("input.links").
+        ExpectStep(StepTitle).
+        Text("Wedding").
+        Text("Write to www.example.com").
+        ExpectRejected("input.links").
+        ExpectStep(StepDescription)
+}
+
+func TestOrderFlowBack(t *testing.T) {
+    fsmtest.New(t, orderFlow).
+        Start(FlowOrder, nil).
+        Back().
+        ExpectCancelled().
+        Start(FlowOrder, nil).
+        Choose(string(model.SpecializationPhotographer)).
+        Text("Wedding").
+        ExpectStep(StepDescription).
+        Back().
+        ExpectPrompt("order.title_prompt").
+        Back().
+        ExpectPrompt("order.specialization_prompt").
+        Choose(string(model.SpecializationVideographer)).
+        Text("Birthday").
+        Text("Three hours").
+        Text("Astana").
+        ExpectDone(fsm.Data{
+            StepSpecialization: string(model.SpecializationVideographer),
+            StepTitle:          "Birthday",
+        })
+}
+
+func TestOrderFlowCancel(t *testing.T) {
+    fsmtest.New(t, orderFlow).
+        Start(FlowOrder, nil).
+        Choose(string(model.SpecializationPhotographer)).
+        Text("Wedding").
+        Cancel().
+        ExpectCancelled().
+        Text("Two cameras").
+        ExpectIgnored()
+}
+
+func TestOrderFlowRejectsNonText(t *testing.T) {
+    fsmtest.New(t, orderFlow).
+        Start(FlowOrder, nil).
+        Text("").
+        ExpectRejected(fsm.RejectUseButtons).
+        Choose(string(model.SpecializationPhotographer)).
+        Text("").
+        ExpectRejected(fsm.RejectTextRequired).
+        ExpectPrompt("order.title_prompt")
+}
+
+func TestOrderFlowIgnoresStaleButtons(t *testing.T) {
+    fsmtest.New(t, orderFlow).
+        Start(FlowOrder, nil).
+        Choose(string(model.SpecializationPhotographer)).
+        ChooseAt(StepSpecialization, string(model.SpecializationVideographer)).
+        ExpectIgnored().
+        Text("Wedding").
+        Text("Two cameras").
+        Text("Almaty").
+        ExpectDone(fsm.Data{StepSpecialization: string(model.SpecializationPhotographer)})
+}
+
+func TestOrderEditFlow(t *testing.T) {
+    for _, step := range []string{StepTitle, StepDescription, StepLocation} {
+        t.Run(step, func(t *testing.T) {
+            fsmtest.New(t, orderEditFlow).
+                StartAt(FlowOrderEdit, step, fsm.Data{DataOrderID: "42"}).
+                ExpectStep(step).
+                Text("  Almaty Arena  ").
+                ExpectDone(fsm.Data{step: "Almaty Arena", DataOrderID: "42"})
+        })
+    }
+}
+
+func TestOrderEditFlowValidation(t *testing.T) {
+    fsmtest.New(t, orderEditFlow).
+        StartAt(FlowOrderEdit, StepDescription, fsm.Data{DataOrderID: "42"}).
+        Text(strings.Repeat("a", maxOrderDescriptionLength+1)).
+        ExpectRejected("input.too_long").
+        Text("").
+        ExpectRejected(fsm.RejectTextRequired).
+        ExpectPrompt("order.description_prompt")
+}
+
+func TestOrderEditFlowBackLeaves(t *testing.T) {
+    // The edit is entered at the field's step, so Back has nowhere to go.
+    fsmtest.New(t, orderEditFlow).
+        StartAt(FlowOrderEdit, StepLocation, fsm.Data{DataOrderID: "42"}).
+        Back().
+        ExpectCancelled()
+}
+
+func TestOrderEditFlowCancel(t *testing.T) {
+    fsmtest.New(t, orderEditFlow).
+        StartAt(FlowOrderEdit, StepTitle, fsm.Data{DataOrderID: "42"}).
+        Cancel().
+        ExpectCancelled().
+        Text("Wedding").
+        ExpectIgnored()
+}
+
+func TestFlowsAreRegistered(t *testing.T) {
+    m := newFlows()
+    for _, flow := range []string{FlowRegistration, FlowExecutorRole, FlowOrder, FlowOrderEdit, FlowResponse, FlowCities, FlowTeam} {
+        if _, err := m.Start(1, flow, nil); err != nil {
+            t.Errorf("flow %q: %v", flow, err)
+        }
+    }
+}
@@ -0,0 +1,240 @@
+package bot
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/callback"
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Commands understood in group chats. /start, which Telegram sends when the
+// bot is added through startGroupURL, links the group as /link does.
+const (
+    CommandLink   = "link"
+    CommandUnlink = "unlink"
+)
+
+// isGroupChat tells group chats, where several people share one chat ID,
+// from private chats with a user.
+func isGroupChat(chat *tgbotapi.Chat) bool {
+    return chat != nil && (chat.IsGroup() || chat.IsSuperGroup())
+}
+
+// isGroupChatID tells group chat IDs, which are negative, from the IDs of
+// private chats, which equal the user's ID.
+func isGroupChatID(chatID int64) bool {
+    return chatID < 0
+}
+
+// startGroupURL asks Telegram to add the bot to a group the user picks.
+func (tg *TgBot) startGroupURL() string {
+    return "https://t.me/" + tg.bot.Self.UserName + "?startgroup=" + CommandLink
+}
+
+// handleGroupMessage handles the messages of group chats, where the bot
+// runs no wizards and only listens to its own commands.
+func (tg *TgBot) handleGroupMessage(log *slog.Logger, message *tgbotapi.Message) {
+    log = log.With(slog.String("handler", "handleGroupMessage"))
+    chatID := message.Chat.ID
+    tg.rememberLang(chatID, message.From, nil)
+
+    switch {
+    case message.MigrateToChatID != 0:
+        if err := tg.groupService.MoveGroup(chatID, message.MigrateToChatID); err != nil {
+            log.Error("failed to move group", sl.Err(err))
+        }
+    case message.LeftChatMember != nil && message.LeftChatMember.ID == tg.bot.Self.ID:
+        if _, err := tg.groupService.UnlinkGroup(chatID); err != nil {
+            log.Error("failed to unlink group", sl.Err(err))
+        }
+        log.Info("bot removed from group")
+    case message.IsCommand() && tg.addressedToBot(message):
+        switch message.Command() {
+        case CommandStart, CommandLink:
+            tg.handleGroupLink(log, message)
+        case CommandUnlink:
+            tg.handleGroupUnlink(log, message)
+        }
+    }
+}
+
+// addressedToBot tells whether a command in a group is meant for this bot
+// rather than another one in the same group.
+func (tg *TgBot) addressedToBot(message *tgbotapi.Message) bool {
+    _, bot, ok := strings.Cut(message.CommandWithAt(), "@")
+    return !ok || strings.EqualFold(bot, tg.bot.Self.UserName)
+}
+
+// isGroupAdmin tells whether a user administers a group. Only they may
+// link and unlink it.
+func (tg *TgBot) isGroupAdmin(log *slog.Logger, chatID, userID int64) bool {
+    member, err := tg.bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+        ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+    })
+    if err != nil {
+        log.Warn("failed to get group member", sl.Err(err))
+        return false
+    }
+    return member.IsCreator() || member.IsAdministrator()
+}
+
+// handleGroupLink links a group to the executor account of the admin who
+// asked, so that their orders come to the group.
+func (tg *TgBot) handleGroupLink(log *slog.Logger, message *tgbotapi.Message) {
+    chatID := message.Chat.ID
+    if message.From == nil || !tg.isGroupAdmin(log, chatID, message.From.ID) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.admins_only")))
+        return
+    }
+
+    account, err := tg.authz.RequireRole(strconv.FormatInt(message.From.ID, 10), model.RoleExecutor)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+
+    group := model.GroupChat{ChatID: chatID, Title: message.Chat.Title, UserID: account.Id}
+    if err := tg.groupService.LinkGroup(group, message.From.ID); err != nil {
+        log.Error("failed to link group", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+    log.Info("group linked", slog.Int("user_id", account.Id))
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.linked", account.Name)))
+}
+
+func (tg *TgBot) handleGroupUnlink(log *slog.Logger, message *tgbotapi.Message) {
+    chatID := message.Chat.ID
+    if message.From == nil || !tg.isGroupAdmin(log, chatID, message.From.ID) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.admins_only")))
+        return
+    }
+
+    unlinked, err := tg.groupService.UnlinkGroup(chatID)
+    if err != nil {
+        log.Error("failed to unlink group", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+    if !unlinked {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.not_linked")))
+        return
+    }
+    log.Info("group unlinked")
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.unlinked")))
+}
+
+// groupAccount returns the account a group acts for.
+func (tg *TgBot) groupAccount(log *slog.Logger, chatID int64) (*model.User, bool) {
+    group, err := tg.groupService.GetGroup(chatID)
+    if errors.Is(err, errs.ErrNotFound) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.not_linked")))
+        return nil, false
+    }
+    if err != nil {
+        log.Error("failed to get group", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return nil, false
+    }
+
+    account, err := tg.service.GetUserByID(group.UserID)
+    if err != nil {
+        log.Error("failed to get group account", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return nil, false
+    }
+    if tg.rejectBanned(log, chatID, account) {
+        return nil, false
+    }
+    return account, true
+}
+
+// handleGroupCallback handles buttons pressed in a group. Members may only
+// respond to the orders sent there.
+func (tg *TgBot) handleGroupCallback(log *slog.Logger, query *tgbotapi.CallbackQuery, payload callback.Payload) {
+    if query.Message == nil {
+        log.Warn("group callback query without a message")
+        return
+    }
+    chatID := query.Message.Chat.ID
+    tg.rememberLang(chatID, query.From, nil)
+
+    if payload.Action != CallbackRespond {
+        log.Warn("callback action not available in groups")
+        return
+    }
+    tg.handleGroupResponse(log, chatID, query.From, query.Message.MessageID, payload.Param(0))
+}
+
+// handleGroupResponse responds to an order on behalf of the account the
+// group is linked to. The note step is skipped, since every member of the
+// group would be answering it.
+func (tg *TgBot) handleGroupResponse(log *slog.Logger, chatID int64, member *tgbotapi.User, messageID int, orderID string) {
+    log = log.With(slog.String("handler", "handleGroupResponse"), slog.String("order_id", orderID))
+
+    account, ok := tg.groupAccount(log, chatID)
+    if !ok {
+        return
+    }
+    if !tg.actsForAccount(log, account, member) {
+        log.Info("group response from an outsider", slog.Int64("member_id", member.ID))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.members_only", member.FirstName)))
+        return
+    }
+
+    executor, order, err := tg.authz.CanRespond(account.ChatId, orderID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    if !tg.saveResponse(log, chatID, executor, &order, "") {
+        return
+    }
+
+    // The button goes, so that other members do not respond again.
+    tg.disarm(log, chatID, messageID)
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "group.responded", member.FirstName, executor.Name, order.Title)))
+
+    tg.notifyCustomerOfResponse(log, executor, &order, "")
+}
+
+// actsForAccount tells whether a group member may act for the account the
+// group is linked to: the account itself or a member of its team. Anyone
+// else in the group only sees the orders.
+func (tg *TgBot) actsForAccount(log *slog.Logger, account *model.User, member *tgbotapi.User) bool {
+    if strconv.FormatInt(member.ID, 10) == account.ChatId {
+        return true
+    }
+    if account.TeamID == 0 {
+        return false
+    }
+    // A user's private chat has the ID of the user.
+    user := tg.currentUser(log, member.ID)
+    return user != nil && !user.Banned && user.TeamID == account.TeamID
+}
+
+// sendToGroups sends an account's groups an order meant for the account.
+// decorate adjusts each message, e.g. to add a heading.
+func (tg *TgBot) sendToGroups(log *slog.Logger, account model.User, order *model.Order, decorate func(*tgbotapi.MessageConfig)) {
+    groups, err := tg.groupService.GetGroupsByUser(account.Id)
+    if err != nil {
+        log.Error("failed to get groups", sl.Err(err))
+        return
+    }
+
+    for _, group := range groups {
+        msg := tg.orderOffer(group.ChatID, userLang(account), order)
+        if decorate != nil {
+            decorate(&msg)
+        }
+        tg.send(log.With(slog.Int64("group_chat_id", group.ChatID)), msg)
+    }
+}
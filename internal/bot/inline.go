@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Inline mode lets users share orders and profiles in any chat by typing
+// "@bot <text>". Every result carries a start link back into the bot.
+const (
+    // inlineCacheTime is how long Telegram may reuse an answer, in
+    // seconds. Results depend on who asks, so it is kept short.
+    inlineCacheTime = 30
+    // inlineSearchLimit is how many orders and how many executors a
+    // search returns.
+    inlineSearchLimit = 10
+    // minSpecializationMatch is the shortest text matched against
+    // specialization names, so that "a" does not match them all.
+    minSpecializationMatch = 3
+    // sharedDescriptionLength caps order descriptions in shared messages.
+    sharedDescriptionLength = 500
+)
+
+// handleInlineQuery answers "@bot" typed in any chat. An empty query offers
+// the user's own profile and open orders, share buttons fill in a start
+// link payload, and other text searches open orders and executors.
+func (tg *TgBot) handleInlineQuery(log *slog.Logger, query *tgbotapi.InlineQuery) {
+    log = log.With(slog.String("handler", "handleInlineQuery"))
+
+    // Private chats with the bot share their ID with the user.
+    user := tg.currentUser(log, query.From.ID)
+    tg.rememberLang(query.From.ID, query.From, user)
+
+    var results []interface{}
+    if user == nil || !user.Banned {
+        results = tg.inlineResults(log, tg.lang(query.From.ID), user, strings.TrimSpace(query.Query))
+    }
+    log.Debug("answering inline query", slog.Int("results", len(results)))
+
+    answer := tgbotapi.InlineConfig{
+        InlineQueryID: query.ID,
+        Results:       results,
+        CacheTime:     inlineCacheTime,
+        IsPersonal:    true,
+    }
+    if _, err := tg.bot.Request(answer); err != nil {
+        tg.metrics.TelegramSendErrors.Inc()
+        log.Warn("failed to answer inline query", sl.Err(err))
+    }
+}
+
+func (tg *TgBot) inlineResults(log *slog.Logger, lang i18n.Lang, user *model.User, text string) []interface{} {
+    var results []interface{}
+
+    if link, ok := parseStartLink(text); ok {
+        switch link.kind {
+        case LinkOrder:
+            order, err := tg.orderService.GetOrderByID(link.value)
+            if err == nil && order.Status == model.OrderStatusOpen {
+                results = append(results, tg.orderResult(lang, &order))
+            }
+        case LinkExecutor:
+            if executor, err := tg.activeExecutor(link.value); err == nil {
+                results = tg.appendProfileResult(log, results, lang, executor)
+            }
+        }
+        return results
+    }
+
+    if text == "" {
+        if user == nil {
+            return nil
+        }
+        if user.HasRole(model.RoleExecutor) {
+            results = tg.appendProfileResult(log, results, lang, user)
+        }
+        if user.HasRole(model.RoleCustomer) {
+            orders, err := tg.orderService.GetOrdersByUser(user.Id, myOrdersLimit)
+            if err != nil {
+                log.Error("failed to get orders", sl.Err(err))
+            }
+            for _, order := range orders {
+                if order.Status == model.OrderStatusOpen {
+                    results = append(results, tg.orderResult(lang, &order))
+                }
+            }
+        }
+        return results
+    }
+
+    specs := matchSpecializations(text)
+    orders, err := tg.orderService.SearchOpenOrders(text, specs, inlineSearchLimit)
+    if err != nil {
+        log.Error("failed to search orders", sl.Err(err))
+    }
+    for _, order := range orders {
+        results = append(results, tg.orderResult(lang, &order))
+    }
+
+    executors, err := tg.service.SearchExecutors(text, specs, inlineSearchLimit)
+    if err != nil {
+        log.Error("failed to search executors", sl.Err(err))
+    }
+    for _, executor := range executors {
+        results = tg.appendProfileResult(log, results, lang, &executor)
+    }
+    return results
+}
+
+// matchSpecializations returns the specializations whose name, in any
+// language, the text mentions or starts to spell.
+func matchSpecializations(text string) []model.Specialization {
+    text = strings.ToLower(text)
+    var specs []model.Specialization
+    for _, spec := range model.Specializations {
+        for _, lang := range i18n.Supported {
+            name := strings.ToLower(specializationName(lang, spec))
+            if strings.Contains(text, name) ||
+                (utf8.RuneCountInString(text) >= minSpecializationMatch && strings.Contains(name, text)) {
+                specs = append(specs, spec)
+                break
+            }
+        }
+    }
+    return specs
+}
+
+// orderResult is an open order to share, with a link for executors to
+// respond in the bot.
+func (tg *TgBot) orderResult(lang i18n.Lang, order *model.Order) tgbotapi.InlineQueryResultArticle {
+    spec := orderSpecialization(lang, order)
+    text := i18n.R(lang, "order.shared", i18n.Vars{
+        "Title":          order.Title,
+        "Specialization": spec,
+        "Description":    truncate(order.Description, sharedDescriptionLength),
+        "Location":       order.Location,
+    })
+
+    result := tgbotapi.NewInlineQueryResultArticleHTML(LinkOrder+"_"+strconv.Itoa(order.ID), order.Title, text)
+    result.Description = fmt.Sprintf("%s · %s", spec, order.Location)
+    keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+        tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "button.respond_in_bot"), tg.startLinkURL(LinkOrder, strconv.Itoa(order.ID))),
+    ))
+    result.ReplyMarkup = &keyboard
+    return result
+}
+
+// appendProfileResult adds an executor's profile card to share.
+func (tg *TgBot) appendProfileResult(log *slog.Logger, results []interface{}, lang i18n.Lang, executor *model.User) []interface{} {
+    text, err := tg.executorCard(lang, executor)
+    if err != nil {
+        log.Error("failed to render profile card", sl.Err(err))
+        return results
+    }
+
+    result := tgbotapi.NewInlineQueryResultArticleHTML(LinkExecutor+"_"+strconv.Itoa(executor.Id), executor.Name, text)
+    result.Description = specializationName(lang, executor.Specialization)
+    keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+        tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "button.open_profile"), tg.startLinkURL(LinkExecutor, strconv.Itoa(executor.Id))),
+    ))
+    result.ReplyMarkup = &keyboard
+    return append(results, result)
+}
+
+// truncate shortens text to at most max runes, marking the cut.
+func truncate(text string, max int) string {
+    if utf8.RuneCountInString(text) <= max {
+        return text
+    }
+    runes := []rune(text)
+    return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
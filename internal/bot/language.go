@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const CommandLanguage = "language"
+
+// rememberLang resolves the interface language of a chat the first time it is
+// seen: a registered user's stored preference wins, otherwise the language
+// of their Telegram client is used.
+func (tg *TgBot) rememberLang(chatID int64, from *tgbotapi.User, user *model.User) {
+    tg.stateMutex.Lock()
+    defer tg.stateMutex.Unlock()
+
+    if user != nil {
+        tg.langs[chatID] = i18n.Detect(user.Language)
+        return
+    }
+    if _, ok := tg.langs[chatID]; ok {
+        return
+    }
+
+    lang := i18n.Default
+    if from != nil {
+        lang = i18n.Detect(from.LanguageCode)
+    }
+    tg.langs[chatID] = lang
+}
+
+func (tg *TgBot) lang(chatID int64) i18n.Lang {
+    tg.stateMutex.Lock()
+    defer tg.stateMutex.Unlock()
+
+    if lang, ok := tg.langs[chatID]; ok {
+        return lang
+    }
+    return i18n.Default
+}
+
+// t translates a message into the language of the given chat.
+func (tg *TgBot) t(chatID int64, key string, args ...any) string {
+    return i18n.T(tg.lang(chatID), key, args...)
+}
+
+// r renders a templated text in the language of the given chat.
+func (tg *TgBot) r(chatID int64, key string, vars i18n.Vars) string {
+    return i18n.R(tg.lang(chatID), key, vars)
+}
+
+// userLang is the language for messages sent to a user other than the one
+// who triggered the update.
+func userLang(user model.User) i18n.Lang {
+    return i18n.Detect(user.Language)
+}
+
+func (tg *TgBot) handleLanguageCommand(log *slog.Logger, chatID int64) {
+    var row []tgbotapi.InlineKeyboardButton
+    for _, lang := range i18n.Supported {
+        row = append(row, tg.button(chatID, i18n.T(lang, "language.native"), CallbackLanguage, string(lang)))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, tg.t(chatID, "language.prompt"))
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+    tg.sendScreen(log, msg)
+}
+
+func (tg *TgBot) handleLanguageSelection(log *slog.Logger, chatID int64, code string) {
+    lang, ok := i18n.Parse(code)
+    if !ok {
+        return
+    }
+    log = log.With(slog.String("handler", "handleLanguageSelection"), slog.String("language", string(lang)))
+
+    tg.stateMutex.Lock()
+    tg.langs[chatID] = lang
+    tg.stateMutex.Unlock()
+
+    // Unregistered users keep the choice in memory until CreateUser stores it.
+    err := tg.service.SetLanguage(strconv.FormatInt(chatID, 10), string(lang))
+    if err != nil && !errors.Is(err, errs.ErrNotFound) {
+        log.Error("failed to save language", sl.Err(err))
+    }
+
+    // Replaces the language picker.
+    tg.sendScreen(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "language.changed")))
+}
+
+// roleName is the display name of a role in the given language.
+func roleName(lang i18n.Lang, role model.Role) string {
+    return i18n.T(lang, "role."+string(role))
+}
+
+// specializationName is the display name of a specialization in the given
+// language, or a dash when the user has none.
+func specializationName(lang i18n.Lang, spec model.Specialization) string {
+    if spec == "" {
+        return "—"
+    }
+    return i18n.T(lang, "specialization."+string(spec))
+}
+
+// orderSpecialization is what an order needs: its specialization, or each
+// slot with its headcount on crew orders.
+func orderSpecialization(lang i18n.Lang, order *model.Order) string {
+    if len(order.Slots) == 0 {
+        return specializationName(lang, order.Specialization)
+    }
+    names := make([]string, len(order.Slots))
+    for i, slot := range order.Slots {
+        names[i] = fmt.Sprintf("%s × %d", specializationName(lang, slot.Specialization), slot.Headcount)
+    }
+    return strings.Join(names, ", ")
+}
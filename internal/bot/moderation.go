@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// moderationQueueSize is how many pending orders /moderation shows at once.
+const moderationQueueSize = 10
+
+// requestModeration sends every admin a review card for an order held by
+// the trust rule. edited marks the card of an order changed after it was
+// submitted, so that admins review the text that will go live rather than
+// an earlier card.
+func (tg *TgBot) requestModeration(log *slog.Logger, order *model.Order, edited bool) {
+    for adminChatID := range tg.admins {
+        tg.sendModerationCard(log.With(slog.Int64("admin_chat_id", adminChatID)), adminChatID, order, edited)
+    }
+}
+
+func (tg *TgBot) adminModeration(log *slog.Logger, chatID int64) {
+    orders, err := tg.orderService.GetPendingOrders(moderationQueueSize)
+    if err != nil {
+        log.Error("failed to get pending orders", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.moderation_error")))
+        return
+    }
+
+    if len(orders) == 0 {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.moderation_empty")))
+        return
+    }
+
+    for i := range orders {
+        // Orders that were live before are back for an edit.
+        tg.sendModerationCard(log, chatID, &orders[i], !orders[i].PublishedAt.IsZero())
+    }
+}
+
+func (tg *TgBot) sendModerationCard(log *slog.Logger, chatID int64, order *model.Order, edited bool) {
+    text := tg.t(chatID, "admin.moderation_card",
+        order.ID,
+        order.Title,
+        orderSpecialization(tg.lang(chatID), order),
+        order.Description,
+        order.Location,
+        order.CreatedAt.Format("02.01.2006 15:04"),
+        order.User.Name,
+        order.User.UserName,
+        order.User.ChatId,
+    )
+    if edited {
+        text = tg.t(chatID, "admin.moderation_edited", order.ID) + "\n\n" + text
+    }
+
+    buttons := [][]tgbotapi.InlineKeyboardButton{
+        {
+            tg.button(chatID, tg.t(chatID, "button.approve"), CallbackModerateApprove, strconv.Itoa(order.ID)),
+            tg.button(chatID, tg.t(chatID, "button.reject"), CallbackModerateReject, strconv.Itoa(order.ID)),
+        },
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.send(log, msg)
+}
+
+func (tg *TgBot) handleModerationDecision(log *slog.Logger, chatID int64, orderID string, approved bool) {
+    action := "moderate_reject"
+    if approved {
+        action = "moderate_approve"
+    }
+    log = log.With(slog.String("handler", "handleModerationDecision"), slog.String("order_id", orderID))
+
+    if err := tg.adminService.LogAction(strconv.FormatInt(chatID, 10), action, orderID, ""); err != nil {
+        log.Error("failed to write audit log", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.audit_error")))
+        return
+    }
+
+    var edited bool
+    var err error
+    if approved {
+        edited, err = tg.orderService.ApproveOrder(orderID)
+    } else {
+        err = tg.orderService.RejectOrder(orderID)
+    }
+    if err != nil {
+        tg.adminFailure(log, chatID, err, "admin.moderation_failed", orderID)
+        return
+    }
+
+    order, err := tg.orderService.GetOrderByID(orderID)
+    if err != nil {
+        log.Error("failed to get order", sl.Err(err))
+        return
+    }
+    log.Info("order moderated", slog.Any("order", order))
+
+    customerChatID, err := strconv.ParseInt(order.User.ChatId, 10, 64)
+    if err != nil {
+        log.Error("failed to parse customer chat id", sl.Err(err))
+        return
+    }
+
+    customerLang := userLang(order.User)
+
+    if !approved {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.order_rejected", orderID)))
+
+        customerMsg := i18n.R(customerLang, "moderation.rejected", i18n.Vars{"Title": order.Title})
+        response := tgbotapi.NewMessage(customerChatID, customerMsg)
+        tg.send(log, response)
+        // A rejected edit takes a live order off the channels.
+        tg.updateChannelPosts(log, &order)
+        return
+    }
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.order_approved", orderID)))
+
+    customerMsg := i18n.R(customerLang, "moderation.approved", i18n.Vars{"Title": order.Title})
+    response := tgbotapi.NewMessage(customerChatID, customerMsg)
+    tg.send(log, response)
+
+    // An approved edit reaches those who saw the order already.
+    if edited {
+        tg.notifyResponders(log, &order)
+        tg.updateChannelPosts(log, &order)
+        return
+    }
+
+    notified := tg.notifyExecutors(log, &order)
+    tg.reportFanout(log, customerChatID, customerLang, notified)
+    tg.publishToChannels(log, &order)
+}
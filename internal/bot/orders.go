@@ -0,0 +1,433 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/markup"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Editable order fields used in CallbackOrderEdit.
+const (
+    OrderFieldTitle       = "title"
+    OrderFieldDescription = "description"
+    OrderFieldLocation    = "location"
+)
+
+// myOrdersLimit is how many orders "My orders" lists.
+const myOrdersLimit = 20
+
+// sendOrderCard shows an order to its owner with the actions its status
+// allows. For drafts this is the preview before publishing.
+func (tg *TgBot) sendOrderCard(log *slog.Logger, chatID int64, order *model.Order) {
+    lang := tg.lang(chatID)
+    text := i18n.T(lang, "order.card",
+        markup.Safe(i18n.T(lang, "order_status."+order.Status)),
+        order.Title,
+        orderSpecialization(lang, order),
+        order.Description,
+        order.Location,
+    )
+
+    var buttons [][]tgbotapi.InlineKeyboardButton
+    switch order.Status {
+    case model.OrderStatusDraft, model.OrderStatusPendingReview, model.OrderStatusOpen:
+        buttons = append(buttons,
+            tgbotapi.NewInlineKeyboardRow(tg.orderEditButton(chatID, lang, order.ID, OrderFieldTitle)),
+            tgbotapi.NewInlineKeyboardRow(tg.orderEditButton(chatID, lang, order.ID, OrderFieldDescription)),
+            tgbotapi.NewInlineKeyboardRow(tg.orderEditButton(chatID, lang, order.ID, OrderFieldLocation)),
+        )
+    }
+    switch order.Status {
+    case model.OrderStatusDraft:
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.crew"), CallbackOrderCrew, strconv.Itoa(order.ID)),
+        ), tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.publish"), CallbackOrderPublish, strconv.Itoa(order.ID)),
+        ))
+    case model.OrderStatusPendingReview:
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.cancel_order"), CallbackOrderCancel, strconv.Itoa(order.ID)),
+        ))
+    case model.OrderStatusOpen:
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.close_order"), CallbackOrderClose, strconv.Itoa(order.ID)),
+            tg.button(chatID, i18n.T(lang, "button.cancel_order"), CallbackOrderCancel, strconv.Itoa(order.ID)),
+        ), tgbotapi.NewInlineKeyboardRow(
+            tgbotapi.NewInlineKeyboardButtonSwitch(i18n.T(lang, "button.share_order"), startLink{LinkOrder, strconv.Itoa(order.ID)}.String()),
+        ))
+    case model.OrderStatusStaffed:
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.close_order"), CallbackOrderClose, strconv.Itoa(order.ID)),
+            tg.button(chatID, i18n.T(lang, "button.cancel_order"), CallbackOrderCancel, strconv.Itoa(order.ID)),
+        ))
+    case model.OrderStatusClosed, model.OrderStatusCancelled, model.OrderStatusRejected:
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.repost"), CallbackOrderRepost, strconv.Itoa(order.ID)),
+        ))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    if len(buttons) > 0 {
+        msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    }
+    tg.sendScreen(log, msg)
+}
+
+func (tg *TgBot) orderEditButton(chatID int64, lang i18n.Lang, orderID int, field string) tgbotapi.InlineKeyboardButton {
+    return tg.button(chatID, i18n.T(lang, "button.edit_"+field), CallbackOrderEdit, field, strconv.Itoa(orderID))
+}
+
+// handleOrderCrew shows how many executors of each specialization a draft
+// needs, with buttons to change the headcounts.
+func (tg *TgBot) handleOrderCrew(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleOrderCrew"), slog.String("order_id", orderID))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+    if order.Status != model.OrderStatusDraft {
+        tg.refuse(log, chatID, fmt.Errorf("order %d is not a draft: %w", order.ID, errs.ErrInvalidState))
+        return
+    }
+    tg.sendCrewScreen(log, chatID, &order)
+}
+
+// handleOrderSlot sets the headcount of one specialization of a draft.
+func (tg *TgBot) handleOrderSlot(log *slog.Logger, chatID int64, orderID, rawSpec, rawHeadcount string) {
+    log = log.With(slog.String("handler", "handleOrderSlot"), slog.String("order_id", orderID))
+
+    spec, err := model.ParseSpecialization(rawSpec)
+    if err != nil {
+        return
+    }
+    headcount, err := strconv.Atoi(rawHeadcount)
+    if err != nil {
+        return
+    }
+    if _, ok := tg.ownOrder(log, chatID, orderID); !ok {
+        return
+    }
+
+    if err := tg.orderService.SetSlot(orderID, spec, headcount); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("order slot set", slog.String("specialization", string(spec)), slog.Int("headcount", headcount))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+    tg.sendCrewScreen(log, chatID, &order)
+}
+
+// sendCrewScreen lists every specialization with the executors the order
+// needs of it. The last executor cannot be removed.
+func (tg *TgBot) sendCrewScreen(log *slog.Logger, chatID int64, order *model.Order) {
+    lang := tg.lang(chatID)
+    id := strconv.Itoa(order.ID)
+
+    var total int
+    for _, spec := range model.Specializations {
+        total += order.Headcount(spec)
+    }
+
+    var buttons [][]tgbotapi.InlineKeyboardButton
+    for _, spec := range model.Specializations {
+        headcount := order.Headcount(spec)
+        label := specializationName(lang, spec)
+        if headcount > 0 {
+            label = fmt.Sprintf("%s × %d", label, headcount)
+        }
+
+        var row []tgbotapi.InlineKeyboardButton
+        if headcount > 0 && total > 1 {
+            row = append(row, tg.button(chatID, "➖", CallbackOrderSlot, id, string(spec), strconv.Itoa(headcount-1)))
+        }
+        row = append(row, tg.button(chatID, label, CallbackOrderCrew, id))
+        if headcount < model.MaxSlotHeadcount {
+            row = append(row, tg.button(chatID, "➕", CallbackOrderSlot, id, string(spec), strconv.Itoa(headcount+1)))
+        }
+        buttons = append(buttons, row)
+    }
+    buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+        tg.button(chatID, i18n.T(lang, "button.back"), CallbackOrderView, id),
+    ))
+
+    msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "crew.screen", orderSpecialization(lang, order)))
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.sendScreen(log, msg)
+}
+
+// saveOrderDraft stores the order collected by the wizard as a draft and
+// shows the preview.
+func (tg *TgBot) saveOrderDraft(log *slog.Logger, chatID int64, order model.Order) {
+    order.Status = model.OrderStatusDraft
+
+    draft, err := tg.orderService.CreateOrder(order)
+    if err != nil {
+        log.Error("failed to save order draft", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.create_error")))
+        return
+    }
+    log.Info("order draft saved", slog.Any("order", draft))
+
+    tg.sendOrderCard(log, chatID, &draft)
+}
+
+func (tg *TgBot) handleMyOrders(log *slog.Logger, chatID int64, user *model.User) {
+    log = log.With(slog.String("handler", "handleMyOrders"))
+
+    orders, err := tg.orderService.GetOrdersByUser(user.Id, myOrdersLimit)
+    if err != nil {
+        log.Error("failed to get orders", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+
+    if len(orders) == 0 {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.list_empty")))
+        return
+    }
+
+    lang := tg.lang(chatID)
+    var buttons [][]tgbotapi.InlineKeyboardButton
+    for _, order := range orders {
+        label := fmt.Sprintf("#%d · %s · %s", order.ID, order.Title, i18n.T(lang, "order_status."+order.Status))
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, label, CallbackOrderView, strconv.Itoa(order.ID)),
+        ))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "order.list"))
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.sendScreen(log, msg)
+}
+
+func (tg *TgBot) handleOrderView(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleOrderView"), slog.String("order_id", orderID))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+    tg.sendOrderCard(log, chatID, &order)
+}
+
+// handleOrderEdit asks for a new value of one field of a draft or a
+// published order. The answer finishes the order_edit flow in
+// finishOrderEdit.
+func (tg *TgBot) handleOrderEdit(log *slog.Logger, chatID int64, field, orderID string) {
+    switch field {
+    case OrderFieldTitle, OrderFieldDescription, OrderFieldLocation:
+    default:
+        return
+    }
+    log = log.With(slog.String("handler", "handleOrderEdit"), slog.String("order_id", orderID), slog.String("field", field))
+
+    if _, ok := tg.ownOrder(log, chatID, orderID); !ok {
+        return
+    }
+
+    // Order fields are named like the steps of the flow that edits them.
+    tg.startFlowAt(log, chatID, FlowOrderEdit, field, fsm.Data{DataOrderID: orderID})
+}
+
+func (tg *TgBot) finishOrderEdit(log *slog.Logger, chatID int64, data fsm.Data) {
+    log = log.With(slog.String("handler", "finishOrderEdit"), slog.String("order_id", data[DataOrderID]))
+
+    // The order is loaded again, since it may have changed while the user
+    // was typing.
+    order, ok := tg.ownOrder(log, chatID, data[DataOrderID])
+    if !ok {
+        return
+    }
+
+    if title, ok := data[StepTitle]; ok {
+        order.Title = title
+    }
+    if description, ok := data[StepDescription]; ok {
+        order.Description = description
+    }
+    if location, ok := data[StepLocation]; ok {
+        order.Location = location
+    }
+
+    status, err := tg.orderService.UpdateOrder(order)
+    if errors.Is(err, errs.ErrInvalidState) {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    if err != nil {
+        log.Error("failed to update order", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.update_error")))
+        return
+    }
+    order.Status = status
+    log.Info("order updated", slog.Any("order", order))
+
+    // Admins review every version of an order held for review, and
+    // executors and channels see the edit of a live one once it is
+    // approved.
+    if order.Status == model.OrderStatusPendingReview {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.updated_pending_review")))
+        tg.sendOrderCard(log, chatID, &order)
+        tg.requestModeration(log, &order, true)
+        return
+    }
+
+    if order.Status != model.OrderStatusDraft {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.updated")))
+    }
+    tg.sendOrderCard(log, chatID, &order)
+
+    if order.Status == model.OrderStatusOpen {
+        tg.notifyResponders(log, &order)
+        tg.updateChannelPosts(log, &order)
+    }
+}
+
+// orderVars are the fields of an order the customer sees in confirmations.
+func orderVars(order *model.Order) i18n.Vars {
+    return i18n.Vars{
+        "Title":       order.Title,
+        "Description": order.Description,
+        "Location":    order.Location,
+    }
+}
+
+// notifyResponders tells executors who already responded to an order that
+// its details changed.
+func (tg *TgBot) notifyResponders(log *slog.Logger, order *model.Order) {
+    responders, err := tg.orderResponseService.GetResponders(order.ID)
+    if err != nil {
+        log.Error("failed to get responders", sl.Err(err))
+        return
+    }
+
+    log.Info("notifying responders about order change", slog.Int("count", len(responders)))
+    for _, responder := range responders {
+        chatID, err := strconv.ParseInt(responder.ChatId, 10, 64)
+        if err != nil {
+            continue
+        }
+        lang := userLang(responder)
+
+        msg := tgbotapi.NewMessage(chatID, i18n.R(lang, "order.changed_notification", orderVars(order)))
+        tg.send(log.With(slog.Int64("executor_chat_id", chatID)), msg)
+    }
+}
+
+// handleOrderPublish takes a draft live: straight to executors, or to the
+// moderation queue while the customer is not trusted yet.
+func (tg *TgBot) handleOrderPublish(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleOrderPublish"), slog.String("order_id", orderID))
+
+    if _, ok := tg.ownOrder(log, chatID, orderID); !ok {
+        return
+    }
+
+    order, err := tg.orderService.PublishOrder(orderID)
+    if errors.Is(err, errs.ErrInvalidState) {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    if err != nil {
+        log.Error("failed to publish order", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.create_error")))
+        return
+    }
+    tg.metrics.OrdersCreated.Inc()
+    log.Info("order published", slog.Any("order", order))
+
+    if order.Status == model.OrderStatusPendingReview {
+        reviewMsg := tg.r(chatID, "order.pending_review", orderVars(&order))
+
+        response := tgbotapi.NewMessage(chatID, reviewMsg)
+        tg.send(log, response)
+
+        tg.requestModeration(log, &order, false)
+        return
+    }
+
+    successMsg := tg.r(chatID, "order.created", orderVars(&order))
+
+    response := tgbotapi.NewMessage(chatID, successMsg)
+    tg.send(log, response)
+
+    notified := tg.notifyExecutors(log, &order)
+    tg.reportFanout(log, chatID, tg.lang(chatID), notified)
+    tg.publishToChannels(log, &order)
+}
+
+func (tg *TgBot) handleOrderClose(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleOrderClose"), slog.String("order_id", orderID))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+
+    if err := tg.orderService.CloseOrder(orderID); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("order closed by customer")
+
+    order.Status = model.OrderStatusClosed
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.closed")))
+    tg.sendOrderCard(log, chatID, &order)
+    tg.updateChannelPosts(log, &order)
+    tg.sendRatingPrompts(log, chatID, &order)
+}
+
+// handleOrderCancel withdraws an order that will not take place. Unlike a
+// closed order, it disappears from the channels it was posted to.
+func (tg *TgBot) handleOrderCancel(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleOrderCancel"), slog.String("order_id", orderID))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+
+    if err := tg.orderService.CancelOrder(orderID); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("order cancelled by customer")
+
+    order.Status = model.OrderStatusCancelled
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "order.cancelled")))
+    tg.sendOrderCard(log, chatID, &order)
+    tg.deleteChannelPosts(log, &order)
+}
+
+// handleOrderRepost copies a closed order into a new draft, which the
+// customer can adjust before publishing it again.
+func (tg *TgBot) handleOrderRepost(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleOrderRepost"), slog.String("order_id", orderID))
+
+    if _, ok := tg.ownOrder(log, chatID, orderID); !ok {
+        return
+    }
+
+    draft, err := tg.orderService.RepostOrder(orderID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("order reposted", slog.Any("order", draft))
+
+    tg.sendOrderCard(log, chatID, &draft)
+}
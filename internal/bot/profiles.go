@@ -0,0 +1,312 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/markup"
+	"github.com/aidosgal/lenshub/internal/model"
+	"github.com/aidosgal/lenshub/internal/service"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxInviteOrders is how many open orders a customer is offered to invite
+// an executor to from their profile.
+const maxInviteOrders = 5
+
+// activeExecutor loads an executor who can be shown to others.
+func (tg *TgBot) activeExecutor(rawID string) (*model.User, error) {
+    id, err := strconv.Atoi(rawID)
+    if err != nil {
+        return nil, fmt.Errorf("invalid executor id %q: %w", rawID, errs.ErrNotFound)
+    }
+    executor, err := tg.service.GetUserByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if !executor.HasRole(model.RoleExecutor) || executor.Banned {
+        return nil, fmt.Errorf("user %d is not an active executor: %w", id, errs.ErrNotFound)
+    }
+    return executor, nil
+}
+
+// executorCard renders an executor's public profile. It leaves out the
+// contacts: customers reach executors through orders.
+func (tg *TgBot) executorCard(lang i18n.Lang, executor *model.User) (string, error) {
+    stats, err := tg.reviewService.GetExecutorStats(executor.Id)
+    if err != nil {
+        return "", err
+    }
+
+    rating := markup.Safe(i18n.T(lang, "profile.no_rating"))
+    if stats.Reviews > 0 {
+        rating = markup.Safe(i18n.N(lang, "profile.rating", stats.Reviews, stats.Rating, stats.Reviews))
+    }
+    var cities any = markup.Safe(i18n.T(lang, "profile.no_cities"))
+    if len(executor.Cities) > 0 {
+        cities = strings.Join(executor.Cities, citiesSeparator)
+    }
+
+    return i18n.R(lang, "executor.public_profile", i18n.Vars{
+        "Name":           executor.Name,
+        "Specialization": specializationName(lang, executor.Specialization),
+        "Rating":         rating,
+        "CompletedJobs":  stats.CompletedJobs,
+        "Cities":         cities,
+    }), nil
+}
+
+// showExecutorProfile shows an executor's public profile. Customers can
+// invite the executor to their open orders of the same specialization.
+func (tg *TgBot) showExecutorProfile(log *slog.Logger, chatID int64, rawID string) {
+    log = log.With(slog.String("handler", "showExecutorProfile"), slog.String("executor_id", rawID))
+
+    executor, err := tg.activeExecutor(rawID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    lang := tg.lang(chatID)
+    text, err := tg.executorCard(lang, executor)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+
+    buttons := [][]tgbotapi.InlineKeyboardButton{
+        tgbotapi.NewInlineKeyboardRow(
+            tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "button.executor_portfolio"), executor.Portfolio),
+        ),
+    }
+    if invites := tg.inviteButtons(log, chatID, executor); len(invites) > 0 {
+        text += "\n\n" + i18n.T(lang, "invite.hint")
+        buttons = append(buttons, invites...)
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.send(log, msg)
+}
+
+// inviteButtons offers the viewer's open orders the executor could take.
+func (tg *TgBot) inviteButtons(log *slog.Logger, chatID int64, executor *model.User) [][]tgbotapi.InlineKeyboardButton {
+    viewer := tg.currentUser(log, chatID)
+    if viewer == nil || viewer.Id == executor.Id || !viewer.HasRole(model.RoleCustomer) {
+        return nil
+    }
+
+    orders, err := tg.orderService.GetOrdersByUser(viewer.Id, myOrdersLimit)
+    if err != nil {
+        log.Error("failed to get orders", sl.Err(err))
+        return nil
+    }
+
+    var rows [][]tgbotapi.InlineKeyboardButton
+    for _, order := range orders {
+        if order.Status != model.OrderStatusOpen || order.ExecutorID != 0 || order.Headcount(executor.Specialization) == 0 {
+            continue
+        }
+        label := fmt.Sprintf("📨 #%d · %s", order.ID, order.Title)
+        rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, label, CallbackInvite, strconv.Itoa(executor.Id), strconv.Itoa(order.ID)),
+        ))
+        if len(rows) == maxInviteOrders {
+            break
+        }
+    }
+    return rows
+}
+
+// handleInvite sends an executor one of the customer's open orders, as it
+// would have come in a notification.
+func (tg *TgBot) handleInvite(log *slog.Logger, chatID int64, executorID, orderID string) {
+    log = log.With(slog.String("handler", "handleInvite"), slog.String("executor_id", executorID), slog.String("order_id", orderID))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+    executor, err := tg.activeExecutor(executorID)
+    switch {
+    case err != nil:
+    case order.Status != model.OrderStatusOpen || order.ExecutorID != 0:
+        err = service.ErrOrderNotOpen
+    case order.Headcount(executor.Specialization) == 0:
+        err = service.ErrSpecializationMismatch
+    }
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+
+    executorChatID, err := strconv.ParseInt(executor.ChatId, 10, 64)
+    if err != nil {
+        log.Error("failed to parse executor chat id", sl.Err(err))
+        return
+    }
+    lang := userLang(*executor)
+    msg := tg.orderOffer(executorChatID, lang, &order)
+    msg.Text = i18n.T(lang, "invite.notification", order.User.Name) + "\n\n" + msg.Text
+    if _, err := tg.send(log.With(slog.Int64("executor_chat_id", executorChatID)), msg); err != nil {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+
+    tg.sendToGroups(log, *executor, &order, func(msg *tgbotapi.MessageConfig) {
+        msg.Text = i18n.T(lang, "invite.notification", order.User.Name) + "\n\n" + msg.Text
+    })
+
+    log.Info("executor invited to order")
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "invite.sent", executor.Name)))
+}
+
+// handleHire records the executor a customer chose among those who
+// responded, and tells the executor.
+func (tg *TgBot) handleHire(log *slog.Logger, chatID int64, executorID, orderID string) {
+    log = log.With(slog.String("handler", "handleHire"), slog.String("executor_id", executorID), slog.String("order_id", orderID))
+
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+    executor, err := tg.activeExecutor(executorID)
+    if err == nil {
+        err = tg.orderService.HireExecutor(orderID, executor.Id)
+    }
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("executor hired")
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "hire.done", executor.Name, order.Title)))
+
+    executorChatID, err := strconv.ParseInt(executor.ChatId, 10, 64)
+    if err != nil {
+        log.Error("failed to parse executor chat id", sl.Err(err))
+        return
+    }
+    lang := userLang(*executor)
+    tg.send(log.With(slog.Int64("executor_chat_id", executorChatID)),
+        tgbotapi.NewMessage(executorChatID, i18n.T(lang, "hire.notification", order.Title)))
+
+    hired, err := tg.orderService.GetOrderByID(orderID)
+    if err != nil {
+        log.Error("failed to get order", sl.Err(err))
+        return
+    }
+    // An executor who responded as a team picks the member who does the job.
+    if hired.TeamID != 0 {
+        tg.sendAssignPrompt(log, executorChatID, lang, &hired)
+    }
+    // The last hire of a crew takes the order off the channels.
+    if hired.Status == model.OrderStatusStaffed {
+        log.Info("crew order staffed")
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "crew.staffed", hired.Title)))
+        tg.updateChannelPosts(log, &hired)
+    }
+}
+
+// sendRatingPrompts asks the customer to rate the executors they hired for
+// an order they just closed: the executor, or each member of the crew.
+func (tg *TgBot) sendRatingPrompts(log *slog.Logger, chatID int64, order *model.Order) {
+    if order.ExecutorID != 0 {
+        tg.sendRatingPrompt(log, chatID, order, order.ExecutorID, tg.t(chatID, "review.prompt", order.Title))
+    }
+    for _, slot := range order.Slots {
+        for _, executorID := range slot.Hired {
+            executor, err := tg.service.GetUserByID(executorID)
+            if err != nil {
+                log.Error("failed to get crew member", sl.Err(err), slog.Int("executor_id", executorID))
+                continue
+            }
+            tg.sendRatingPrompt(log, chatID, order, executorID, tg.t(chatID, "review.prompt_member", executor.Name, order.Title))
+        }
+    }
+}
+
+func (tg *TgBot) sendRatingPrompt(log *slog.Logger, chatID int64, order *model.Order, executorID int, text string) {
+    var row []tgbotapi.InlineKeyboardButton
+    for stars := 1; stars <= 5; stars++ {
+        row = append(row, tg.button(chatID, fmt.Sprintf("%d ⭐", stars), CallbackRate, strconv.Itoa(order.ID), strconv.Itoa(stars), strconv.Itoa(executorID)))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+    tg.send(log, msg)
+}
+
+// handleRate stores a rating from a prompt. Prompts sent before crews
+// existed carry no executor and rate the one the order was hired for.
+func (tg *TgBot) handleRate(log *slog.Logger, chatID int64, orderID, rawRating, rawExecutorID string) {
+    log = log.With(slog.String("handler", "handleRate"), slog.String("order_id", orderID))
+
+    id, err := strconv.Atoi(orderID)
+    if err != nil {
+        return
+    }
+    rating, err := strconv.Atoi(rawRating)
+    if err != nil || rating < 1 || rating > 5 {
+        return
+    }
+    customer, ok := tg.authorizeRole(log, chatID, model.RoleCustomer)
+    if !ok {
+        return
+    }
+
+    var executorID int
+    if rawExecutorID != "" {
+        if executorID, err = strconv.Atoi(rawExecutorID); err != nil {
+            return
+        }
+    } else {
+        order, err := tg.orderService.GetOrderByID(orderID)
+        if err != nil {
+            tg.refuse(log, chatID, err)
+            return
+        }
+        executorID = order.ExecutorID
+    }
+
+    err = tg.reviewService.RateExecutor(id, customer.Id, executorID, rating)
+    if errors.Is(err, errs.ErrAlreadyExists) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "review.duplicate")))
+        return
+    }
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("executor rated", slog.Int("executor_id", executorID), slog.Int("rating", rating))
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "review.thanks")))
+}
+
+// handleEditCities asks an executor for the cities they work in.
+func (tg *TgBot) handleEditCities(log *slog.Logger, chatID int64) {
+    if _, ok := tg.authorizeRole(log, chatID, model.RoleExecutor); ok {
+        tg.startFlow(log, chatID, FlowCities, nil)
+    }
+}
+
+func (tg *TgBot) finishCities(log *slog.Logger, chatID int64, data fsm.Data) {
+    log = log.With(slog.String("handler", "finishCities"))
+
+    cities := strings.Split(data[StepCities], citiesSeparator)
+    if err := tg.service.SetCities(strconv.FormatInt(chatID, 10), cities); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("executor cities updated", slog.Int("count", len(cities)))
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "profile.cities_saved")))
+    if user := tg.currentUser(log, chatID); user != nil {
+        tg.showUserProfile(log, chatID, user)
+    }
+}
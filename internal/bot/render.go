@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/markup"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// withParseMode marks texts without a parse mode as HTML, the format of the
+// catalogs.
+func withParseMode(c tgbotapi.Chattable) tgbotapi.Chattable {
+    switch m := c.(type) {
+    case tgbotapi.MessageConfig:
+        if m.ParseMode == "" {
+            m.ParseMode = tgbotapi.ModeHTML
+        }
+        return m
+    case tgbotapi.EditMessageTextConfig:
+        if m.ParseMode == "" {
+            m.ParseMode = tgbotapi.ModeHTML
+        }
+        return m
+    }
+    return c
+}
+
+// plainText turns a formatted text into the same text without entities.
+// It reports false for requests that carry no text.
+func plainText(c tgbotapi.Chattable) (tgbotapi.Chattable, bool) {
+    switch m := c.(type) {
+    case tgbotapi.MessageConfig:
+        m.Text = markup.Plain(markup.Mode(m.ParseMode), m.Text)
+        m.ParseMode = ""
+        m.Entities = nil
+        return m, true
+    case tgbotapi.EditMessageTextConfig:
+        m.Text = markup.Plain(markup.Mode(m.ParseMode), m.Text)
+        m.ParseMode = ""
+        m.Entities = nil
+        return m, true
+    }
+    return nil, false
+}
+
+// isEntityParseError reports whether Telegram refused a text because of its
+// markup ("Bad Request: can't parse entities: ...").
+func isEntityParseError(err error) bool {
+    return strings.Contains(err.Error(), "can't parse entities")
+}
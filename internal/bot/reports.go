@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	"github.com/aidosgal/lenshub/internal/service"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// reportQueueSize is how many pending reports /reports shows at once.
+const reportQueueSize = 10
+
+// currentUser loads the registered user behind a chat, or nil if the chat
+// has not registered yet or the lookup failed.
+func (tg *TgBot) currentUser(log *slog.Logger, chatID int64) *model.User {
+    user, err := tg.service.GetUserByChatID(strconv.FormatInt(chatID, 10))
+    if errors.Is(err, errs.ErrNotFound) {
+        return nil
+    }
+    if err != nil {
+        log.Error("failed to get current user", sl.Err(err))
+        return nil
+    }
+    return user
+}
+
+// rejectBanned reports whether the chat belongs to a banned user, telling
+// the user so. Banned users cannot do anything in the bot.
+func (tg *TgBot) rejectBanned(log *slog.Logger, chatID int64, user *model.User) bool {
+    if user == nil || !user.Banned {
+        return false
+    }
+
+    log.Info("ignoring update from banned user")
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "banned")))
+    return true
+}
+
+// handleReportOrder files a report from an executor about an order and the
+// customer who posted it.
+func (tg *TgBot) handleReportOrder(log *slog.Logger, chatID int64, orderID string) {
+    log = log.With(slog.String("handler", "handleReportOrder"), slog.String("order_id", orderID))
+
+    reporter, err := tg.authz.Actor(strconv.FormatInt(chatID, 10))
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+
+    order, err := tg.orderService.GetOrderByID(orderID)
+    if err != nil {
+        log.Error("failed to get order", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+
+    tg.fileReport(log, chatID, reporter.Id, order.User.Id, order.ID)
+}
+
+// handleReportExecutor files a report from a customer about an executor who
+// responded to their order.
+func (tg *TgBot) handleReportExecutor(log *slog.Logger, chatID int64, executorID, orderID string) {
+    log = log.With(slog.String("handler", "handleReportExecutor"), slog.String("order_id", orderID))
+
+    targetID, err := strconv.Atoi(executorID)
+    if err != nil {
+        return
+    }
+    // Only the customer who received the response can report it.
+    order, ok := tg.ownOrder(log, chatID, orderID)
+    if !ok {
+        return
+    }
+
+    tg.fileReport(log, chatID, order.User.Id, targetID, order.ID)
+}
+
+func (tg *TgBot) fileReport(log *slog.Logger, chatID int64, reporterID, targetID, orderID int) {
+    created, err := tg.reportService.CreateReport(reporterID, targetID, orderID)
+    if errors.Is(err, service.ErrSelfReport) {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    if err != nil {
+        log.Error("failed to create report", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "report.error")))
+        return
+    }
+
+    if !created {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "report.duplicate")))
+        return
+    }
+
+    log.Info("report created", slog.Int("target_user_id", targetID))
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "report.sent")))
+}
+
+func (tg *TgBot) adminReports(log *slog.Logger, chatID int64) {
+    reports, err := tg.reportService.GetPendingReports(reportQueueSize)
+    if err != nil {
+        log.Error("failed to get reports", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.reports_error")))
+        return
+    }
+
+    if len(reports) == 0 {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.reports_empty")))
+        return
+    }
+
+    for _, report := range reports {
+        tg.sendReportCard(log, chatID, report)
+    }
+}
+
+func (tg *TgBot) sendReportCard(log *slog.Logger, chatID int64, report model.Report) {
+    order := "—"
+    if report.OrderID != 0 {
+        order = fmt.Sprintf("#%d", report.OrderID)
+    }
+
+    text := tg.t(chatID, "admin.report_card",
+        report.ID,
+        report.CreatedAt.Format("02.01.2006 15:04"),
+        report.Target.Name,
+        report.Target.UserName,
+        roleName(tg.lang(chatID), report.Target.Role),
+        report.Target.ChatId,
+        order,
+        report.Reporter.Name,
+        report.Reporter.UserName,
+        report.Reporter.ChatId,
+    )
+
+    buttons := [][]tgbotapi.InlineKeyboardButton{
+        {
+            tg.button(chatID, tg.t(chatID, "button.ban"), CallbackReportBan, strconv.Itoa(report.ID)),
+            tg.button(chatID, tg.t(chatID, "button.dismiss"), CallbackReportDismiss, strconv.Itoa(report.ID)),
+        },
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.send(log, msg)
+}
+
+func (tg *TgBot) handleReportResolution(log *slog.Logger, chatID int64, rawID string, ban bool) {
+    action := "report_dismiss"
+    if ban {
+        action = "report_ban"
+    }
+    reportID, err := strconv.Atoi(rawID)
+    if err != nil {
+        return
+    }
+    log = log.With(slog.String("handler", "handleReportResolution"), slog.Int("report_id", reportID))
+    adminChatID := strconv.FormatInt(chatID, 10)
+
+    report, err := tg.reportService.GetReportByID(reportID)
+    if err != nil {
+        tg.adminFailure(log, chatID, err, "admin.report_error")
+        return
+    }
+
+    status := model.ReportStatusDismissed
+    if ban {
+        status = model.ReportStatusBanned
+    }
+
+    if err := tg.adminService.LogAction(adminChatID, action, report.Target.ChatId, fmt.Sprintf("report #%d", reportID)); err != nil {
+        log.Error("failed to write audit log", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.audit_error")))
+        return
+    }
+
+    if err := tg.reportService.ResolveReport(reportID, status, adminChatID); err != nil {
+        tg.adminFailure(log, chatID, err, "admin.report_resolve_error")
+        return
+    }
+
+    if status == model.ReportStatusBanned {
+        if err := tg.banUser(log, report.Target.ChatId); err != nil {
+            tg.adminFailure(log, chatID, err, "admin.ban_error")
+            return
+        }
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.report_banned", reportID, report.Target.ChatId)))
+        return
+    }
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "admin.report_dismissed", reportID)))
+}
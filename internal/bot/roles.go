@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// otherRole is the role a user can switch to or add from their profile.
+func otherRole(role model.Role) model.Role {
+    if role == model.RoleCustomer {
+        return model.RoleExecutor
+    }
+    return model.RoleCustomer
+}
+
+// roleSwitcherRow offers to switch to the user's other role, or to take it
+// on if they do not hold it yet.
+func (tg *TgBot) roleSwitcherRow(chatID int64, user *model.User) []tgbotapi.InlineKeyboardButton {
+    other := otherRole(user.Role)
+    if user.HasRole(other) {
+        return tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, tg.t(chatID, "button.switch_to_"+string(other)), CallbackSwitchRole, string(other)),
+        )
+    }
+    return tgbotapi.NewInlineKeyboardRow(
+        tg.button(chatID, tg.t(chatID, "button.add_"+string(other)), CallbackAddRole, string(other)),
+    )
+}
+
+func (tg *TgBot) handleRoleSwitch(log *slog.Logger, chatID int64, user *model.User, rawRole string) {
+    role, err := model.ParseRole(rawRole)
+    if err != nil || user == nil {
+        return
+    }
+    log = log.With(slog.String("handler", "handleRoleSwitch"), slog.String("role", string(role)))
+
+    if err := tg.service.SetActiveRole(user.ChatId, role); err != nil {
+        log.Error("failed to switch role", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+    user.Role = role
+    tg.flows.Cancel(chatID)
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "role.switched", roleName(tg.lang(chatID), role))))
+    tg.showUserProfile(log, chatID, user)
+}
+
+// handleAddRole gives a registered user their second role. Customers need
+// no extra data; executors go through the portfolio and specialization
+// steps of the executor_role flow first, which finishes in
+// finishRegistration.
+func (tg *TgBot) handleAddRole(log *slog.Logger, chatID int64, user *model.User, rawRole string) {
+    role, err := model.ParseRole(rawRole)
+    if err != nil || user == nil {
+        return
+    }
+    log = log.With(slog.String("handler", "handleAddRole"), slog.String("role", string(role)))
+
+    if user.HasRole(role) {
+        tg.handleRoleSwitch(log, chatID, user, rawRole)
+        return
+    }
+
+    if role == model.RoleExecutor {
+        tg.startFlow(log, chatID, FlowExecutorRole, fsm.Data{DataName: user.Name, DataUserName: user.UserName})
+        return
+    }
+
+    err = tg.service.AddRole(model.User{ChatId: strconv.FormatInt(chatID, 10), Role: role})
+    if errors.Is(err, errs.ErrAlreadyExists) {
+        // Added by an earlier press of the same button.
+        tg.handleRoleSwitch(log, chatID, user, rawRole)
+        return
+    }
+    if err != nil {
+        log.Error("failed to add role", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "registration.error")))
+        return
+    }
+    log.Info("role added")
+
+    user.Role = role
+    user.Roles = append(user.Roles, role)
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.r(chatID, "registration.customer_done", i18n.Vars{"Name": user.Name})))
+    tg.showUserProfile(log, chatID, user)
+}
@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Interactive screens are the messages whose buttons drive the
+// conversation: wizard prompts, the profile, order cards and lists. Each
+// chat has at most one live screen. A new screen replaces the live one in
+// place when nothing was said after it; otherwise it is sent anew and the
+// old one loses its buttons, so stale keyboards cannot be pressed again.
+// Notifications, such as new orders for executors, are not screens and
+// keep their buttons.
+
+// noteMessage records the newest message of a chat, sent by either side.
+func (tg *TgBot) noteMessage(chatID int64, messageID int) {
+    tg.stateMutex.Lock()
+    defer tg.stateMutex.Unlock()
+
+    if messageID > tg.latest[chatID] {
+        tg.latest[chatID] = messageID
+    }
+}
+
+// sendScreen shows msg as the chat's live screen.
+func (tg *TgBot) sendScreen(log *slog.Logger, msg tgbotapi.MessageConfig) {
+    chatID := msg.ChatID
+
+    tg.stateMutex.Lock()
+    screen := tg.screens[chatID]
+    editable := screen != 0 && screen == tg.latest[chatID]
+    tg.stateMutex.Unlock()
+
+    if editable {
+        edit := tgbotapi.NewEditMessageText(chatID, screen, msg.Text)
+        edit.ParseMode = msg.ParseMode
+        if keyboard, ok := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup); ok {
+            edit.ReplyMarkup = &keyboard
+        }
+        if tg.edit(log, edit) == nil {
+            return
+        }
+    }
+
+    sent, err := tg.send(log, msg)
+    if err != nil {
+        return
+    }
+    if screen != 0 {
+        tg.disarm(log, chatID, screen)
+    }
+
+    tg.stateMutex.Lock()
+    tg.screens[chatID] = sent.MessageID
+    tg.stateMutex.Unlock()
+}
+
+// clearScreen takes the buttons off the live screen once its question is
+// answered and no new screen follows right away.
+func (tg *TgBot) clearScreen(log *slog.Logger, chatID int64) {
+    tg.stateMutex.Lock()
+    screen := tg.screens[chatID]
+    delete(tg.screens, chatID)
+    tg.stateMutex.Unlock()
+
+    if screen != 0 {
+        tg.disarm(log, chatID, screen)
+    }
+}
+
+// disarm removes the inline keyboard of a message.
+func (tg *TgBot) disarm(log *slog.Logger, chatID int64, messageID int) {
+    empty := tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
+    tg.edit(log, tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, empty))
+}
+
+// edit changes a message the bot sent before, with the same parse mode
+// handling as send. An edit that changes nothing counts as done.
+func (tg *TgBot) edit(log *slog.Logger, c tgbotapi.Chattable) error {
+    c = withParseMode(c)
+    _, err := tg.bot.Request(c)
+    if err != nil && isEntityParseError(err) {
+        if plain, ok := plainText(c); ok {
+            log.Warn("failed to parse message entities, editing with plain text", sl.Err(err))
+            _, err = tg.bot.Request(plain)
+        }
+    }
+    if err != nil && strings.Contains(err.Error(), "message is not modified") {
+        return nil
+    }
+    if err != nil {
+        tg.metrics.TelegramSendErrors.Inc()
+        log.Warn("failed to edit message", sl.Err(err))
+    }
+    return err
+}
+
+// answerCallback stops the loading indicator on the pressed button.
+// Telegram expects an answer to every callback query.
+func (tg *TgBot) answerCallback(log *slog.Logger, callbackQueryID, text string) {
+    if _, err := tg.bot.Request(tgbotapi.NewCallback(callbackQueryID, text)); err != nil {
+        tg.metrics.TelegramSendErrors.Inc()
+        log.Warn("failed to answer callback query", sl.Err(err))
+    }
+}
@@ -0,0 +1,462 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/i18n"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/markup"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxTeamPortfolios is how many members' portfolios a team profile links
+// to.
+const maxTeamPortfolios = 10
+
+func teamRoleName(lang i18n.Lang, role model.TeamRole) string {
+    return i18n.T(lang, "team_role."+string(role))
+}
+
+// teamCard renders a team's profile: the members and the track record they
+// share.
+func (tg *TgBot) teamCard(lang i18n.Lang, team *model.Team) (string, error) {
+    stats, err := tg.teamService.GetTeamStats(team.ID)
+    if err != nil {
+        return "", err
+    }
+
+    rating := markup.Safe(i18n.T(lang, "profile.no_rating"))
+    if stats.Reviews > 0 {
+        rating = markup.Safe(i18n.N(lang, "profile.rating", stats.Reviews, stats.Rating, stats.Reviews))
+    }
+    rows := make([]string, 0, len(team.Members))
+    for _, member := range team.Members {
+        rows = append(rows, i18n.T(lang, "team.member_row",
+            member.User.Name,
+            specializationName(lang, member.User.Specialization),
+            teamRoleName(lang, member.Role),
+        ))
+    }
+
+    return i18n.R(lang, "team.profile", i18n.Vars{
+        "Name":          team.Name,
+        "Rating":        rating,
+        "CompletedJobs": stats.CompletedJobs,
+        "Members":       markup.Safe(strings.Join(rows, "\n")),
+    }), nil
+}
+
+// handleTeam shows an executor their team, or lets them create one.
+func (tg *TgBot) handleTeam(log *slog.Logger, chatID int64) {
+    user, ok := tg.authorizeRole(log, chatID, model.RoleExecutor)
+    if !ok {
+        return
+    }
+    if user.TeamID == 0 {
+        tg.startFlow(log, chatID, FlowTeam, nil)
+        return
+    }
+    tg.showTeam(log, chatID, user)
+}
+
+// showTeam is the screen members manage their team on. The owner gets the
+// invite link and a button for each member.
+func (tg *TgBot) showTeam(log *slog.Logger, chatID int64, user *model.User) {
+    log = log.With(slog.String("handler", "showTeam"), slog.Int("team_id", user.TeamID))
+
+    team, err := tg.teamService.GetTeam(user.TeamID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    lang := tg.lang(chatID)
+    text, err := tg.teamCard(lang, &team)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+
+    var buttons [][]tgbotapi.InlineKeyboardButton
+    if user.TeamRole == model.TeamRoleOwner {
+        link := tg.startLinkURL(LinkTeam, team.InviteCode)
+        text += "\n\n" + i18n.T(lang, "team.invite_link", link)
+
+        for _, member := range team.Members {
+            if member.User.Id == user.Id {
+                continue
+            }
+            label := fmt.Sprintf("👤 %s · %s", member.User.Name, teamRoleName(lang, member.Role))
+            buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+                tg.button(chatID, label, CallbackTeamMember, strconv.Itoa(member.User.Id)),
+            ))
+        }
+
+        share := url.Values{"url": {link}, "text": {i18n.T(lang, "team.invite_text", team.Name)}}
+        buttons = append(buttons,
+            tgbotapi.NewInlineKeyboardRow(
+                tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "button.team_invite"), "https://t.me/share/url?"+share.Encode()),
+            ),
+            tgbotapi.NewInlineKeyboardRow(
+                tg.button(chatID, i18n.T(lang, "button.team_disband"), CallbackTeamDisband),
+            ),
+        )
+    } else {
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, i18n.T(lang, "button.team_leave"), CallbackTeamLeave),
+        ))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.sendScreen(log, msg)
+}
+
+func (tg *TgBot) finishTeam(log *slog.Logger, chatID int64, data fsm.Data) {
+    log = log.With(slog.String("handler", "finishTeam"))
+
+    user, ok := tg.authorizeRole(log, chatID, model.RoleExecutor)
+    if !ok {
+        return
+    }
+
+    team, err := tg.teamService.CreateTeam(data[StepTeamName], user.Id)
+    if errors.Is(err, errs.ErrAlreadyExists) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.already_member")))
+        return
+    }
+    if err != nil {
+        log.Error("failed to create team", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+    log.Info("team created", slog.Int("team_id", team.ID))
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.created", team.Name)))
+    user.TeamID, user.TeamRole = team.ID, model.TeamRoleOwner
+    tg.showTeam(log, chatID, user)
+}
+
+// showTeamInvite offers an executor who opened an invite link to join the
+// team.
+func (tg *TgBot) showTeamInvite(log *slog.Logger, chatID int64, code string) {
+    user, ok := tg.authorizeRole(log, chatID, model.RoleExecutor)
+    if !ok {
+        return
+    }
+    team, err := tg.teamService.GetTeamByInviteCode(code)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    if user.TeamID == team.ID {
+        tg.showTeam(log, chatID, user)
+        return
+    }
+    if user.TeamID != 0 {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.already_member")))
+        return
+    }
+
+    lang := tg.lang(chatID)
+    text, err := tg.teamCard(lang, &team)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    msg := tgbotapi.NewMessage(chatID, text+"\n\n"+i18n.T(lang, "team.join_prompt", team.Name))
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+        tg.button(chatID, i18n.T(lang, "button.team_join"), CallbackTeamJoin, code),
+    ))
+    tg.send(log, msg)
+}
+
+func (tg *TgBot) handleTeamJoin(log *slog.Logger, chatID int64, code string) {
+    log = log.With(slog.String("handler", "handleTeamJoin"))
+
+    user, ok := tg.authorizeRole(log, chatID, model.RoleExecutor)
+    if !ok {
+        return
+    }
+    team, err := tg.teamService.GetTeamByInviteCode(code)
+    if err == nil {
+        err = tg.teamService.AddMember(team.ID, user.Id)
+    }
+    if errors.Is(err, errs.ErrAlreadyExists) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.already_member")))
+        return
+    }
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("joined team", slog.Int("team_id", team.ID))
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.joined", team.Name)))
+    user.TeamID, user.TeamRole = team.ID, model.TeamRoleMember
+    tg.showTeam(log, chatID, user)
+
+    if owner, ok := team.Member(team.OwnerID); ok {
+        tg.notifyMember(log, owner.User, "team.member_joined", user.Name, team.Name)
+    }
+}
+
+// notifyMember tells a member of a team about a change.
+func (tg *TgBot) notifyMember(log *slog.Logger, member model.User, key string, args ...any) {
+    chatID, err := strconv.ParseInt(member.ChatId, 10, 64)
+    if err != nil {
+        log.Error("failed to parse member chat id", sl.Err(err))
+        return
+    }
+    tg.send(log.With(slog.Int64("member_chat_id", chatID)), tgbotapi.NewMessage(chatID, i18n.T(userLang(member), key, args...)))
+}
+
+// teamMember loads the team of the acting owner and the member they picked.
+func (tg *TgBot) teamMember(log *slog.Logger, chatID int64, rawID string) (model.Team, model.TeamMember, bool) {
+    owner, err := tg.authz.TeamMember(strconv.FormatInt(chatID, 10), model.TeamRoleOwner)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return model.Team{}, model.TeamMember{}, false
+    }
+    team, err := tg.teamService.GetTeam(owner.TeamID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return model.Team{}, model.TeamMember{}, false
+    }
+
+    id, _ := strconv.Atoi(rawID)
+    member, ok := team.Member(id)
+    if !ok || member.Role == model.TeamRoleOwner {
+        tg.refuse(log, chatID, fmt.Errorf("member %q of team %d: %w", rawID, team.ID, errs.ErrNotFound))
+        return model.Team{}, model.TeamMember{}, false
+    }
+    return team, member, true
+}
+
+// handleTeamMember lets the owner change a member's role or remove them.
+func (tg *TgBot) handleTeamMember(log *slog.Logger, chatID int64, rawID string) {
+    log = log.With(slog.String("handler", "handleTeamMember"), slog.String("member_id", rawID))
+
+    _, member, ok := tg.teamMember(log, chatID, rawID)
+    if !ok {
+        return
+    }
+    lang := tg.lang(chatID)
+
+    roleButton := tg.button(chatID, i18n.T(lang, "button.team_make_manager"), CallbackTeamRole, rawID, string(model.TeamRoleManager))
+    if member.Role == model.TeamRoleManager {
+        roleButton = tg.button(chatID, i18n.T(lang, "button.team_make_member"), CallbackTeamRole, rawID, string(model.TeamRoleMember))
+    }
+
+    text := i18n.T(lang, "team.member",
+        member.User.Name,
+        specializationName(lang, member.User.Specialization),
+        teamRoleName(lang, member.Role),
+    )
+    msg := tgbotapi.NewMessage(chatID, text)
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+        tgbotapi.NewInlineKeyboardRow(roleButton),
+        tgbotapi.NewInlineKeyboardRow(tg.button(chatID, i18n.T(lang, "button.team_remove"), CallbackTeamRemove, rawID)),
+        tgbotapi.NewInlineKeyboardRow(tg.button(chatID, i18n.T(lang, "button.back"), CallbackTeam)),
+    )
+    tg.sendScreen(log, msg)
+}
+
+func (tg *TgBot) handleTeamRole(log *slog.Logger, chatID int64, rawID, rawRole string) {
+    role, err := model.ParseTeamRole(rawRole)
+    if err != nil {
+        return
+    }
+    log = log.With(slog.String("handler", "handleTeamRole"), slog.String("member_id", rawID))
+
+    team, member, ok := tg.teamMember(log, chatID, rawID)
+    if !ok {
+        return
+    }
+
+    if err := tg.teamService.SetMemberRole(team.ID, member.User.Id, role); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("team role changed", slog.String("role", rawRole))
+
+    tg.notifyMember(log, member.User, "team.role_changed", team.Name, teamRoleName(userLang(member.User), role))
+    tg.handleTeamMember(log, chatID, rawID)
+}
+
+func (tg *TgBot) handleTeamRemove(log *slog.Logger, chatID int64, rawID string) {
+    log = log.With(slog.String("handler", "handleTeamRemove"), slog.String("member_id", rawID))
+
+    team, member, ok := tg.teamMember(log, chatID, rawID)
+    if !ok {
+        return
+    }
+    if err := tg.teamService.RemoveMember(team.ID, member.User.Id); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("team member removed")
+
+    tg.notifyMember(log, member.User, "team.removed", team.Name)
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.member_removed", member.User.Name)))
+    tg.handleTeam(log, chatID)
+}
+
+// handleTeamLeave lets a member leave their team. The owner disbands it
+// instead.
+func (tg *TgBot) handleTeamLeave(log *slog.Logger, chatID int64) {
+    log = log.With(slog.String("handler", "handleTeamLeave"))
+
+    user, err := tg.authz.TeamMember(strconv.FormatInt(chatID, 10), model.TeamRoleManager, model.TeamRoleMember)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    team, err := tg.teamService.GetTeam(user.TeamID)
+    if err == nil {
+        err = tg.teamService.RemoveMember(team.ID, user.Id)
+    }
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("left team", slog.Int("team_id", team.ID))
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.left", team.Name)))
+    if owner, ok := team.Member(team.OwnerID); ok {
+        tg.notifyMember(log, owner.User, "team.member_left", user.Name, team.Name)
+    }
+}
+
+func (tg *TgBot) handleTeamDisband(log *slog.Logger, chatID int64) {
+    log = log.With(slog.String("handler", "handleTeamDisband"))
+
+    owner, err := tg.authz.TeamMember(strconv.FormatInt(chatID, 10), model.TeamRoleOwner)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    team, err := tg.teamService.GetTeam(owner.TeamID)
+    if err == nil {
+        err = tg.teamService.DisbandTeam(team.ID)
+    }
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("team disbanded", slog.Int("team_id", team.ID))
+
+    for _, member := range team.Members {
+        tg.notifyMember(log, member.User, "team.disbanded", team.Name)
+    }
+}
+
+// showTeamProfile shows a team to customers, with the portfolios of its
+// members.
+func (tg *TgBot) showTeamProfile(log *slog.Logger, chatID int64, rawID string) {
+    log = log.With(slog.String("handler", "showTeamProfile"), slog.String("team_id", rawID))
+
+    id, err := strconv.Atoi(rawID)
+    if err != nil {
+        tg.refuse(log, chatID, fmt.Errorf("invalid team id %q: %w", rawID, errs.ErrNotFound))
+        return
+    }
+    team, err := tg.teamService.GetTeam(id)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    lang := tg.lang(chatID)
+    text, err := tg.teamCard(lang, &team)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+
+    var buttons [][]tgbotapi.InlineKeyboardButton
+    for _, member := range team.Members {
+        if member.User.Portfolio == "" {
+            continue
+        }
+        if len(buttons) == maxTeamPortfolios {
+            break
+        }
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tgbotapi.NewInlineKeyboardButtonURL(fmt.Sprintf("🎨 %s", member.User.Name), member.User.Portfolio),
+        ))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, text)
+    if len(buttons) > 0 {
+        msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    }
+    tg.send(log, msg)
+}
+
+// sendAssignPrompt asks the manager whose team was hired which member
+// takes the order.
+func (tg *TgBot) sendAssignPrompt(log *slog.Logger, chatID int64, lang i18n.Lang, order *model.Order) {
+    team, err := tg.teamService.GetTeam(order.TeamID)
+    if err != nil {
+        log.Error("failed to get team", sl.Err(err))
+        return
+    }
+
+    var buttons [][]tgbotapi.InlineKeyboardButton
+    for _, member := range team.Members {
+        label := fmt.Sprintf("👤 %s · %s", member.User.Name, specializationName(lang, member.User.Specialization))
+        buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+            tg.button(chatID, label, CallbackTeamAssign, strconv.Itoa(order.ID), strconv.Itoa(member.User.Id)),
+        ))
+    }
+
+    msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "team.assign_prompt", order.Title))
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+    tg.send(log, msg)
+}
+
+// handleTeamAssign hands an order the team was hired for to a member, who
+// is then rated for it.
+func (tg *TgBot) handleTeamAssign(log *slog.Logger, chatID int64, orderID, rawID string) {
+    log = log.With(slog.String("handler", "handleTeamAssign"), slog.String("order_id", orderID), slog.String("member_id", rawID))
+
+    manager, err := tg.authz.TeamMember(strconv.FormatInt(chatID, 10), model.TeamRoleOwner, model.TeamRoleManager)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    team, err := tg.teamService.GetTeam(manager.TeamID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    id, _ := strconv.Atoi(rawID)
+    member, ok := team.Member(id)
+    if !ok {
+        tg.refuse(log, chatID, fmt.Errorf("member %q of team %d: %w", rawID, team.ID, errs.ErrNotFound))
+        return
+    }
+
+    if err := tg.teamService.AssignMember(orderID, team.ID, member.User.Id); err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    order, err := tg.orderService.GetOrderByID(orderID)
+    if err != nil {
+        tg.refuse(log, chatID, err)
+        return
+    }
+    log.Info("order assigned to team member")
+
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "team.assign_done", order.Title, member.User.Name)))
+    if member.User.Id != manager.Id {
+        tg.notifyMember(log, member.User, "team.assigned", team.Name, order.Title, order.Location)
+    }
+}
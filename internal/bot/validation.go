@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aidosgal/lenshub/internal/fsm"
+)
+
+// Limits of the order fields, matching the columns of the orders table.
+const (
+    minOrderTitleLength       = 3
+    maxOrderTitleLength       = 255
+    maxOrderDescriptionLength = 10000
+    maxOrderLocationLength    = 255
+)
+
+// Limits of the cities an executor works in.
+const (
+    maxCities       = 5
+    minCityLength   = 2
+    maxCityLength   = 64
+    citiesSeparator = ", "
+)
+
+// Limits of team names, matching teams.name.
+const (
+    minTeamNameLength = 2
+    maxTeamNameLength = 64
+)
+
+// textRule describes what a free-text answer may contain. The fsm machine
+// has already trimmed it and rejected empty and non-text messages.
+type textRule struct {
+    min, max   int
+    allowLinks bool
+}
+
+var (
+    orderTitleRule       = textRule{min: minOrderTitleLength, max: maxOrderTitleLength}
+    orderDescriptionRule = textRule{max: maxOrderDescriptionLength}
+    // Locations may point to a map.
+    orderLocationRule = textRule{max: maxOrderLocationLength, allowLinks: true}
+    cityRule          = textRule{min: minCityLength, max: maxCityLength}
+    teamNameRule      = textRule{min: minTeamNameLength, max: maxTeamNameLength}
+)
+
+// validate is a fsm.Step Validate function enforcing the rule.
+func (r textRule) validate(text string) (string, error) {
+    length := utf8.RuneCountInString(text)
+    if r.min > 0 && length < r.min {
+        return "", &fsm.Reject{Key: "input.too_short", Args: []any{r.min}}
+    }
+    if r.max > 0 && length > r.max {
+        return "", &fsm.Reject{Key: "input.too_long", Args: []any{r.max, length}}
+    }
+    if !r.allowLinks && containsLink(text) {
+        return "", &fsm.Reject{Key: "input.links"}
+    }
+    if containsProfanity(text) {
+        return "", &fsm.Reject{Key: "input.profanity"}
+    }
+    return text, nil
+}
+
+// validateCities accepts a comma-separated list of cities. Duplicates are
+// dropped and the list is normalized to citiesSeparator.
+func validateCities(text string) (string, error) {
+    var cities []string
+    seen := make(map[string]bool)
+    for _, city := range strings.Split(text, ",") {
+        city = strings.TrimSpace(city)
+        if city == "" || seen[strings.ToLower(city)] {
+            continue
+        }
+        if _, err := cityRule.validate(city); err != nil {
+            return "", err
+        }
+        seen[strings.ToLower(city)] = true
+        cities = append(cities, city)
+    }
+
+    if len(cities) == 0 {
+        return "", &fsm.Reject{Key: "input.text_required"}
+    }
+    if len(cities) > maxCities {
+        return "", &fsm.Reject{Key: "profile.too_many_cities", Args: []any{maxCities}}
+    }
+    return strings.Join(cities, citiesSeparator), nil
+}
+
+// linkPattern matches web links and Telegram handles, which would let users
+// take deals off the platform. Bare domains are only caught with common
+// top-level domains, so that "e.g." or "10.5" pass.
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|(^|[^\w@])@[a-z][a-z0-9_]{4,}|\b[a-z0-9-]+\.(com|net|org|ru|kz|io|me|info|co|app|dev|site|online|pro|link|ly|tv|ua|by|uz)\b)`)
+
+func containsLink(text string) bool {
+    return linkPattern.MatchString(text)
+}
+
+// profaneRoots are the beginnings of obscene words in Russian, Kazakh and
+// English. A word is profane when it starts with one of them, possibly
+// after a verbal prefix, which keeps words like "колебание" clean.
+var profaneRoots = []string{
+    "хуй", "хуе", "хуя", "пизд", "ебан", "ебат", "ебал", "ебну", "бляд", "блят",
+    "мудак", "мудил", "залуп", "пидор", "пидар", "гандон", "шлюх",
+    "сігей", "сігіп", "қотақ",
+    "fuck", "shit", "bitch", "cunt", "asshole", "motherf",
+}
+
+var profanePrefixes = []string{
+    "", "на", "по", "за", "у", "вы", "от", "до", "при", "раз", "рас",
+    "об", "подъ", "съ", "въ", "объ", "долбо", "о",
+}
+
+// lookalikes turns latin letters used to disguise Cyrillic words into
+// their Cyrillic twins.
+var lookalikes = strings.NewReplacer(
+    "a", "а", "e", "е", "o", "о", "p", "р", "c", "с", "x", "х", "y", "у", "k", "к",
+)
+
+func containsProfanity(text string) bool {
+    text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+    words := strings.FieldsFunc(text, func(r rune) bool {
+        return !unicode.IsLetter(r)
+    })
+    for _, word := range words {
+        if isProfane(word) || (hasCyrillic(word) && isProfane(lookalikes.Replace(word))) {
+            return true
+        }
+    }
+    return false
+}
+
+func isProfane(word string) bool {
+    for _, prefix := range profanePrefixes {
+        rest, ok := strings.CutPrefix(word, prefix)
+        if !ok {
+            continue
+        }
+        for _, root := range profaneRoots {
+            if strings.HasPrefix(rest, root) {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+func hasCyrillic(word string) bool {
+    for _, r := range word {
+        if unicode.Is(unicode.Cyrillic, r) {
+            return true
+        }
+    }
+    return false
+}
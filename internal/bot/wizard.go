@@ -0,0 +1,190 @@
+package bot
+
+import (
+	"log/slog"
+
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+	"github.com/aidosgal/lenshub/internal/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+    CommandStart  = "start"
+    CommandCancel = "cancel"
+)
+
+// startFlow puts the chat on a flow, dropping whatever it was doing, and
+// asks the first question.
+func (tg *TgBot) startFlow(log *slog.Logger, chatID int64, flow string, data fsm.Data) {
+    out, err := tg.flows.Start(chatID, flow, data)
+    if err != nil {
+        log.Error("failed to start flow", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+    tg.render(log, chatID, out)
+}
+
+// startFlowAt is startFlow for flows entered in the middle.
+func (tg *TgBot) startFlowAt(log *slog.Logger, chatID int64, flow, step string, data fsm.Data) {
+    out, err := tg.flows.StartAt(chatID, flow, step, data)
+    if err != nil {
+        log.Error("failed to start flow", sl.Err(err))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "error.generic")))
+        return
+    }
+    tg.render(log, chatID, out)
+}
+
+// handleFlowInput passes a message to the chat's flow and reports whether
+// there was one to take it.
+func (tg *TgBot) handleFlowInput(log *slog.Logger, message *tgbotapi.Message) bool {
+    out, ok := tg.flows.Handle(message.Chat.ID, fsm.Text(message.Text))
+    if !ok {
+        return false
+    }
+    tg.render(log, message.Chat.ID, out)
+    return true
+}
+
+// handleFlowChoice passes a pressed answer button to the chat's flow.
+func (tg *TgBot) handleFlowChoice(log *slog.Logger, chatID int64, step, value string) {
+    out, ok := tg.flows.Handle(chatID, fsm.Choice(step, value))
+    if !ok {
+        log.Debug("ignoring button of an inactive step", slog.String("step", step))
+        return
+    }
+    tg.render(log, chatID, out)
+}
+
+// render shows what the machine answered: a rejection and the question
+// again, the next question, or the result of a finished flow.
+func (tg *TgBot) render(log *slog.Logger, chatID int64, out fsm.Output) {
+    if out.Done {
+        log.Debug("flow finished", slog.String("flow", out.Flow))
+        tg.clearScreen(log, chatID)
+        tg.finishFlow(log, chatID, out.Flow, out.Data)
+        return
+    }
+
+    if out.Rejected != nil {
+        log.Debug("answer rejected", slog.String("flow", out.Flow), slog.String("step", out.Step), slog.String("reason", out.Rejected.Key))
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, out.Rejected.Key, out.Rejected.Args...)))
+    }
+
+    if out.Prompt != nil {
+        tg.sendPrompt(log, chatID, out.Step, out.Prompt)
+    }
+}
+
+// finishFlow acts on the answers of a completed flow.
+func (tg *TgBot) finishFlow(log *slog.Logger, chatID int64, flow string, data fsm.Data) {
+    switch flow {
+    case FlowRegistration:
+        tg.finishRegistration(log, chatID, data)
+        tg.followStartLink(log, chatID, data)
+    case FlowExecutorRole:
+        tg.finishRegistration(log, chatID, data)
+    case FlowOrder:
+        tg.finishOrder(log, chatID, data)
+    case FlowOrderEdit:
+        tg.finishOrderEdit(log, chatID, data)
+    case FlowResponse:
+        tg.handleOrderResponse(log, chatID, data[DataOrderID], data[StepNote])
+    case FlowCities:
+        tg.finishCities(log, chatID, data)
+    case FlowTeam:
+        tg.finishTeam(log, chatID, data)
+    }
+}
+
+// sendPrompt asks a step's question with its buttons and the "Back" and
+// "Cancel" row.
+func (tg *TgBot) sendPrompt(log *slog.Logger, chatID int64, step string, prompt *fsm.Prompt) {
+    var rows [][]tgbotapi.InlineKeyboardButton
+    for _, row := range prompt.Buttons {
+        var buttons []tgbotapi.InlineKeyboardButton
+        for _, b := range row {
+            buttons = append(buttons, tg.button(chatID, tg.t(chatID, b.Key), CallbackWizardChoose, step, b.Value))
+        }
+        rows = append(rows, buttons)
+    }
+    rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+        tg.button(chatID, tg.t(chatID, "button.back"), CallbackWizardBack),
+        tg.button(chatID, tg.t(chatID, "button.cancel"), CallbackWizardCancel),
+    ))
+
+    msg := tgbotapi.NewMessage(chatID, tg.t(chatID, prompt.Key))
+    msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+    tg.sendScreen(log, msg)
+}
+
+// handleWizardBack returns to the previous step and asks its question
+// again. On the first step it works like /cancel.
+func (tg *TgBot) handleWizardBack(log *slog.Logger, chatID int64, user *model.User) {
+    out, ok := tg.flows.Back(chatID)
+    if !ok {
+        return
+    }
+
+    if out.Cancelled {
+        tg.sendCancelled(log, chatID, user)
+        return
+    }
+    log.Debug("wizard step back", slog.String("flow", out.Flow), slog.String("step", out.Step))
+    tg.render(log, chatID, out)
+}
+
+// handleCancel leaves the current flow, if any, and brings a registered
+// user back to their profile.
+func (tg *TgBot) handleCancel(log *slog.Logger, chatID int64, user *model.User) {
+    if !tg.flows.Cancel(chatID) {
+        tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "wizard.nothing_to_cancel")))
+        return
+    }
+    tg.sendCancelled(log, chatID, user)
+}
+
+func (tg *TgBot) sendCancelled(log *slog.Logger, chatID int64, user *model.User) {
+    log.Debug("wizard cancelled")
+    tg.clearScreen(log, chatID)
+    tg.send(log, tgbotapi.NewMessage(chatID, tg.t(chatID, "wizard.cancelled")))
+    if user != nil {
+        tg.showUserProfile(log, chatID, user)
+    }
+}
+
+// handleStart always begins from a clean slate: any flow in progress is
+// dropped before showing the profile or the registration. A start link
+// payload opens what the link points to, after the registration for new
+// users.
+func (tg *TgBot) handleStart(log *slog.Logger, chatID int64, from *tgbotapi.User, user *model.User, payload string) {
+    tg.flows.Cancel(chatID)
+
+    link, linked := parseStartLink(payload)
+    if linked {
+        tg.trackStartLink(log, chatID, link, user != nil)
+    } else if payload != "" {
+        log.Info("ignoring unknown start payload", slog.String("payload", payload))
+    }
+
+    if user != nil {
+        if linked {
+            tg.openStartLink(log, chatID, user, link)
+            return
+        }
+        tg.showUserProfile(log, chatID, user)
+        return
+    }
+
+    data := fsm.Data{}
+    if from != nil {
+        data[DataName] = from.FirstName
+        data[DataUserName] = from.UserName
+    }
+    if linked {
+        data[DataStartLink] = link.String()
+    }
+    tg.startFlow(log, chatID, FlowRegistration, data)
+}
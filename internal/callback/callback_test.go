@@ -0,0 +1,164 @@
+package callback
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testChat = 123456789
+
+func newTestCodec() *Codec {
+	return NewCodec([]byte("test secret"))
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := newTestCodec()
+	tests := []struct {
+		action string
+		params []string
+	}{
+		{"mo", nil},
+		{"ov", []string{"42"}},
+		{"tr", []string{"1001", "manager"}},
+		{"rs", []string{""}},
+		{"wc", []string{"note", ""}},
+	}
+
+	for _, tt := range tests {
+		data, err := c.Encode(testChat, tt.action, tt.params...)
+		if err != nil {
+			t.Fatalf("encode %s %v: %v", tt.action, tt.params, err)
+		}
+		payload, err := c.Decode(testChat, data)
+		if err != nil {
+			t.Fatalf("decode %q: %v", data, err)
+		}
+		if payload.Action != tt.action {
+			t.Errorf("action = %q, want %q", payload.Action, tt.action)
+		}
+		want := tt.params
+		if want == nil {
+			want = []string{}
+		}
+		if !reflect.DeepEqual(payload.Params, want) {
+			t.Errorf("params = %q, want %q", payload.Params, want)
+		}
+	}
+}
+
+func TestParam(t *testing.T) {
+	p := Payload{Action: "rt", Params: []string{"7", "5"}}
+	if p.Param(1) != "5" {
+		t.Errorf("Param(1) = %q, want 5", p.Param(1))
+	}
+	if p.Param(2) != "" {
+		t.Errorf("Param(2) = %q, want empty", p.Param(2))
+	}
+}
+
+func TestRejectsTamperedData(t *testing.T) {
+	c := newTestCodec()
+	data, err := c.Encode(testChat, "ov", "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := strings.LastIndex(data, separator)
+	signature := data[i+1:]
+	flipped := []byte(signature)
+	flipped[0] ^= 1
+
+	for name, tampered := range map[string]string{
+		"parameter": strings.Replace(data, ":42:", ":43:", 1),
+		"action":    strings.Replace(data, ":ov:", ":oc:", 1),
+		"extra":     data[:i] + ":1" + data[i:],
+		"signature": data[:i+1] + string(flipped),
+		"unsigned":  data[:i+1],
+	} {
+		if _, err := c.Decode(testChat, tampered); !errors.Is(err, ErrSignature) {
+			t.Errorf("%s: decode %q: err = %v, want ErrSignature", name, tampered, err)
+		}
+	}
+}
+
+func TestRejectsOtherChat(t *testing.T) {
+	c := newTestCodec()
+	data, err := c.Encode(testChat, "ov", "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Decode(testChat+1, data); !errors.Is(err, ErrSignature) {
+		t.Errorf("decode in another chat: err = %v, want ErrSignature", err)
+	}
+	if _, err := c.Decode(-testChat, data); !errors.Is(err, ErrSignature) {
+		t.Errorf("decode in a group: err = %v, want ErrSignature", err)
+	}
+}
+
+func TestRejectsOtherKey(t *testing.T) {
+	data, err := newTestCodec().Encode(testChat, "ov", "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCodec([]byte("new token")).Decode(testChat, data); !errors.Is(err, ErrSignature) {
+		t.Errorf("decode with another key: err = %v, want ErrSignature", err)
+	}
+}
+
+func TestRejectsUnknownVersion(t *testing.T) {
+	c := newTestCodec()
+	// Signed correctly, so only the version can be the reason.
+	body := "2:ov:42"
+	data := body + separator + c.sign(testChat, body)
+
+	if _, err := c.Decode(testChat, data); !errors.Is(err, ErrVersion) {
+		t.Errorf("err = %v, want ErrVersion", err)
+	}
+}
+
+func TestRejectsMalformedData(t *testing.T) {
+	c := newTestCodec()
+	for _, data := range []string{"", "ov", "1:", "1::sig", "mo"} {
+		if _, err := c.Decode(testChat, data); !errors.Is(err, ErrMalformed) {
+			t.Errorf("decode %q: err = %v, want ErrMalformed", data, err)
+		}
+	}
+}
+
+func TestEncodeRejectsSeparator(t *testing.T) {
+	c := newTestCodec()
+	if _, err := c.Encode(testChat, "o:v"); !errors.Is(err, ErrMalformed) {
+		t.Errorf("action with separator: err = %v, want ErrMalformed", err)
+	}
+	if _, err := c.Encode(testChat, ""); !errors.Is(err, ErrMalformed) {
+		t.Errorf("empty action: err = %v, want ErrMalformed", err)
+	}
+	if _, err := c.Encode(testChat, "ov", "4:2"); !errors.Is(err, ErrMalformed) {
+		t.Errorf("parameter with separator: err = %v, want ErrMalformed", err)
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	c := newTestCodec()
+	// "1:" + action + ":" + signature takes 15 bytes besides the action and
+	// its parameters.
+	overhead := len(version+separator+separator) + len(c.sign(testChat, ""))
+
+	fits := strings.Repeat("a", MaxLength-overhead-len("ov:"))
+	data, err := c.Encode(testChat, "ov", fits)
+	if err != nil {
+		t.Fatalf("encode %d bytes: %v", MaxLength, err)
+	}
+	if len(data) != MaxLength {
+		t.Fatalf("encoded %d bytes, want exactly %d", len(data), MaxLength)
+	}
+	if _, err := c.Decode(testChat, data); err != nil {
+		t.Fatalf("decode at the limit: %v", err)
+	}
+
+	if _, err := c.Encode(testChat, "ov", fits+"a"); !errors.Is(err, ErrTooLong) {
+		t.Errorf("one byte over the limit: err = %v, want ErrTooLong", err)
+	}
+}
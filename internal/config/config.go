@@ -2,15 +2,32 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/aidosgal/lenshub/internal/model"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
 	Env      string         `yaml:"env" env-default:"local"`
+	// LogLevel is one of debug, info, warn or error. When empty the level
+	// is derived from Env.
+	LogLevel string `yaml:"log_level"`
     Telegram string         `yaml:"telegram"`
+	// Admins are the chat IDs allowed to run admin commands.
+	Admins   []int64        `yaml:"admins"`
 	Database DatabaseConfig `yaml:"database"`
+	// HTTPServer is optional: health, readiness and metrics endpoints are
+	// only served when an address is set.
+	HTTPServer HTTPServerConfig `yaml:"http_server"`
+	Moderation ModerationConfig `yaml:"moderation"`
+	// TemplatesDir holds files overriding the embedded message templates,
+	// e.g. ru.tmpl. They are reloaded on SIGHUP.
+	TemplatesDir string `yaml:"templates_dir"`
+	// Channels are the Telegram channels new orders are posted to.
+	Channels []ChannelConfig `yaml:"channels"`
 }
 
 type DatabaseConfig struct {
@@ -22,6 +39,31 @@ type DatabaseConfig struct {
 	SSLMode  string `yaml:"sslmode"`
 }
 
+type ModerationConfig struct {
+	// FirstOrders is how many of a customer's orders are held for admin
+	// approval before the customer is trusted. Zero disables moderation.
+	FirstOrders int `yaml:"first_orders" env-default:"1"`
+}
+
+type ChannelConfig struct {
+	// ChatID of the channel. The bot has to be an admin allowed to post
+	// and delete messages there.
+	ChatID int64 `yaml:"chat_id"`
+	// Specializations and Cities select the orders posted to the channel.
+	// An empty list lets every order through. Cities are looked up in the
+	// location of the order.
+	Specializations []model.Specialization `yaml:"specializations"`
+	Cities          []string               `yaml:"cities"`
+	// Language of the posts, e.g. kk. Defaults to Russian.
+	Language string `yaml:"language"`
+}
+
+type HTTPServerConfig struct {
+	Address     string        `yaml:"address"`
+	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
+	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+}
+
 func MustLoad() *Config {
 	path := fetchConfigPath()
 	if path == "" {
@@ -41,10 +83,26 @@ func MustLoadByPath(path string) *Config {
 	if err := cleanenv.ReadConfig(path, &cfg); err != nil {
 		panic("failed to read config: " + err.Error())
 	}
+	if err := cfg.validate(); err != nil {
+		panic("invalid config: " + err.Error())
+	}
 
 	return &cfg
 }
 
+// validate catches values that would otherwise fail silently, e.g. a
+// misspelled specialization that keeps a channel from getting any order.
+func (cfg *Config) validate() error {
+	for _, channel := range cfg.Channels {
+		for _, spec := range channel.Specializations {
+			if _, err := model.ParseSpecialization(string(spec)); err != nil {
+				return fmt.Errorf("channel %d: %w", channel.ChatID, err)
+			}
+		}
+	}
+	return nil
+}
+
 func fetchConfigPath() string {
 	var res string
 
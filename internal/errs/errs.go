@@ -0,0 +1,52 @@
+// Package errs defines the errors services and repositories report for
+// outcomes their callers act on. They are wrapped with %w on the way up,
+// so errors.Is finds them under any context added to the message.
+package errs
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrNotFound reports that the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists reports that an entity exists already and may not
+	// be created twice.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrForbidden reports that the actor may not do what they asked.
+	ErrForbidden = errors.New("forbidden")
+	// ErrInvalidState reports that an entity exists but its state does not
+	// allow the change, such as closing an order that is closed already.
+	ErrInvalidState = errors.New("invalid state")
+	// ErrInvalid reports a value the data model does not allow, such as an
+	// unknown specialization or a headcount out of range.
+	ErrInvalid = errors.New("invalid value")
+)
+
+// Postgres error codes of a violated unique constraint or primary key, and
+// of a violated CHECK constraint.
+const (
+	uniqueViolation = pq.ErrorCode("23505")
+	checkViolation  = pq.ErrorCode("23514")
+)
+
+// DB classifies an error of database/sql: no rows become ErrNotFound, a
+// unique violation ErrAlreadyExists and a CHECK violation ErrInvalid. The
+// original error stays wrapped as well. Other errors, nil included, are
+// returned as is.
+func DB(err error) error {
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case errors.As(err, &pqErr) && pqErr.Code == uniqueViolation:
+		return fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+	case errors.As(err, &pqErr) && pqErr.Code == checkViolation:
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	return err
+}
@@ -0,0 +1,328 @@
+// Package fsm runs the bot's multi-step conversations. A Flow declares its
+// steps: what each step asks, which answers it accepts and where it leads.
+// A Machine keeps every chat's progress and turns the user's inputs into
+// Outputs, which the bot renders. The package knows nothing about Telegram
+// or translations: prompts and errors are message keys.
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Finish is the Next result that completes a flow.
+const Finish = ""
+
+// Data holds the answers collected so far, keyed by step name, along with
+// whatever the flow was started with.
+type Data map[string]string
+
+func (d Data) clone() Data {
+	c := make(Data, len(d))
+	for k, v := range d {
+		c[k] = v
+	}
+	return c
+}
+
+// Button is an answer offered at a step. Key is the message key of its
+// label.
+type Button struct {
+	Key   string
+	Value string
+}
+
+// Prompt is the question a step asks.
+type Prompt struct {
+	Key     string
+	Buttons [][]Button
+}
+
+// Step is one question of a flow. Its answer is stored in Data under the
+// step's name.
+type Step struct {
+	Name   string
+	Prompt Prompt
+	// FreeText lets a step with buttons take a typed answer as well. Steps
+	// without buttons always take text.
+	FreeText bool
+	// Validate checks and normalizes a typed answer, which has already been
+	// trimmed and found non-empty. It returns a *Reject to ask again.
+	Validate func(text string) (string, error)
+	// Next picks the following step; nil or Finish completes the flow.
+	Next func(data Data) string
+}
+
+// Goto is a Next that always leads to the named step.
+func Goto(step string) func(Data) string {
+	return func(Data) string { return step }
+}
+
+func (s *Step) acceptsText() bool {
+	return s.FreeText || len(s.Prompt.Buttons) == 0
+}
+
+func (s *Step) hasChoice(value string) bool {
+	for _, row := range s.Prompt.Buttons {
+		for _, b := range row {
+			if b.Value == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Flow is a named conversation. It starts at its first step unless told
+// otherwise.
+type Flow struct {
+	Name  string
+	Steps []Step
+}
+
+func (f *Flow) step(name string) *Step {
+	for i := range f.Steps {
+		if f.Steps[i].Name == name {
+			return &f.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Reject explains why an answer was not accepted. Key is the message key
+// shown to the user before the step is asked again.
+type Reject struct {
+	Key  string
+	Args []any
+}
+
+func (r *Reject) Error() string {
+	return "input rejected: " + r.Key
+}
+
+// Reject keys used by the machine itself.
+const (
+	RejectTextRequired = "input.text_required"
+	RejectUseButtons   = "input.use_buttons"
+	RejectInvalid      = "input.invalid"
+)
+
+// Input is what the user sent at a step.
+type Input struct {
+	Text string
+	// Step and Choice are set when a button was pressed; Step is the step
+	// the button belonged to, so that buttons of earlier steps are ignored.
+	Step   string
+	Choice string
+	choice bool
+}
+
+// Text is a typed answer. Messages without text, such as photos, are passed
+// as Text("") and rejected by the machine.
+func Text(text string) Input {
+	return Input{Text: text}
+}
+
+// Choice is a pressed button.
+func Choice(step, value string) Input {
+	return Input{Step: step, Choice: value, choice: true}
+}
+
+// Output is the machine's answer to an input.
+type Output struct {
+	Flow string
+	// Step is the step the chat is now on; empty once the flow is over.
+	Step string
+	// Prompt is the question to ask next; nil once the flow is over.
+	Prompt *Prompt
+	// Rejected is set when the answer was not accepted; Prompt then asks
+	// the same step again.
+	Rejected *Reject
+	// Done is set when the last step was answered, Cancelled when the user
+	// left the flow. Data holds the answers either way.
+	Done      bool
+	Cancelled bool
+	Data      Data
+}
+
+type session struct {
+	flow    *Flow
+	step    string
+	history []string
+	data    Data
+}
+
+func (s *session) output() Output {
+	step := s.flow.step(s.step)
+	prompt := step.Prompt
+	return Output{Flow: s.flow.Name, Step: s.step, Prompt: &prompt, Data: s.data.clone()}
+}
+
+// Machine tracks which step of which flow every chat is on.
+type Machine struct {
+	flows map[string]*Flow
+
+	mu       sync.Mutex
+	sessions map[int64]*session
+}
+
+// New builds a machine running the given flows. It panics on empty or
+// duplicate flows, since those are programming errors.
+func New(flows ...*Flow) *Machine {
+	m := &Machine{
+		flows:    make(map[string]*Flow, len(flows)),
+		sessions: make(map[int64]*session),
+	}
+	for _, f := range flows {
+		if len(f.Steps) == 0 {
+			panic(fmt.Sprintf("fsm: flow %q has no steps", f.Name))
+		}
+		if _, ok := m.flows[f.Name]; ok {
+			panic(fmt.Sprintf("fsm: duplicate flow %q", f.Name))
+		}
+		m.flows[f.Name] = f
+	}
+	return m
+}
+
+// Start puts the chat on the first step of a flow, abandoning any flow it
+// was in. Data seeds the answers, e.g. with the ID of the order at hand.
+func (m *Machine) Start(chatID int64, flow string, data Data) (Output, error) {
+	f, ok := m.flows[flow]
+	if !ok {
+		return Output{}, fmt.Errorf("fsm: unknown flow %q", flow)
+	}
+	return m.StartAt(chatID, flow, f.Steps[0].Name, data)
+}
+
+// StartAt is Start for flows entered in the middle.
+func (m *Machine) StartAt(chatID int64, flow, step string, data Data) (Output, error) {
+	f, ok := m.flows[flow]
+	if !ok {
+		return Output{}, fmt.Errorf("fsm: unknown flow %q", flow)
+	}
+	if f.step(step) == nil {
+		return Output{}, fmt.Errorf("fsm: flow %q has no step %q", flow, step)
+	}
+
+	s := &session{flow: f, step: step, data: data.clone()}
+
+	m.mu.Lock()
+	m.sessions[chatID] = s
+	m.mu.Unlock()
+
+	return s.output(), nil
+}
+
+// Active reports the flow and step the chat is on.
+func (m *Machine) Active(chatID int64) (flow, step string, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[chatID]
+	if !ok {
+		return "", "", false
+	}
+	return s.flow.Name, s.step, true
+}
+
+// Handle feeds an answer to the chat's current step. It reports false when
+// the chat is in no flow or the input was a button of another step.
+func (m *Machine) Handle(chatID int64, in Input) (Output, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[chatID]
+	if !ok {
+		return Output{}, false
+	}
+	step := s.flow.step(s.step)
+
+	var value string
+	switch {
+	case in.choice:
+		if in.Step != s.step {
+			return Output{}, false
+		}
+		if !step.hasChoice(in.Choice) {
+			return m.reject(s, &Reject{Key: RejectUseButtons}), true
+		}
+		value = in.Choice
+	case !step.acceptsText():
+		return m.reject(s, &Reject{Key: RejectUseButtons}), true
+	default:
+		value = strings.TrimSpace(in.Text)
+		if value == "" {
+			return m.reject(s, &Reject{Key: RejectTextRequired}), true
+		}
+		if step.Validate != nil {
+			v, err := step.Validate(value)
+			if err != nil {
+				var reject *Reject
+				if !errors.As(err, &reject) {
+					reject = &Reject{Key: RejectInvalid}
+				}
+				return m.reject(s, reject), true
+			}
+			value = v
+		}
+	}
+
+	s.data[step.Name] = value
+
+	next := Finish
+	if step.Next != nil {
+		next = step.Next(s.data)
+	}
+	if next == Finish {
+		delete(m.sessions, chatID)
+		return Output{Flow: s.flow.Name, Done: true, Data: s.data.clone()}, true
+	}
+	if s.flow.step(next) == nil {
+		panic(fmt.Sprintf("fsm: flow %q has no step %q", s.flow.Name, next))
+	}
+
+	s.history = append(s.history, s.step)
+	s.step = next
+	return s.output(), true
+}
+
+func (m *Machine) reject(s *session, reject *Reject) Output {
+	out := s.output()
+	out.Rejected = reject
+	return out
+}
+
+// Back returns the chat to the previous step. On the first step it leaves
+// the flow, like Cancel. It reports false when the chat is in no flow.
+func (m *Machine) Back(chatID int64) (Output, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[chatID]
+	if !ok {
+		return Output{}, false
+	}
+
+	if len(s.history) == 0 {
+		delete(m.sessions, chatID)
+		return Output{Flow: s.flow.Name, Cancelled: true, Data: s.data.clone()}, true
+	}
+
+	s.step = s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+	return s.output(), true
+}
+
+// Cancel drops the chat's flow with everything it collected. It reports
+// whether there was one.
+func (m *Machine) Cancel(chatID int64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.sessions[chatID]
+	delete(m.sessions, chatID)
+	return ok
+}
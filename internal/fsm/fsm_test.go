@@ -0,0 +1,240 @@
+package fsm_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aidosgal/lenshub/internal/fsm"
+	"github.com/aidosgal/lenshub/internal/fsm/fsmtest"
+)
+
+// testFlow asks for a kind by button, then for a name, which must not
+// start with a digit, and for a city only when the kind is "event".
+var testFlow = &fsm.Flow{
+	Name: "test",
+	Steps: []fsm.Step{
+		{
+			Name: "kind",
+			Prompt: fsm.Prompt{Key: "kind.prompt", Buttons: [][]fsm.Button{{
+				{Key: "kind.event", Value: "event"},
+				{Key: "kind.other", Value: "other"},
+			}}},
+			Next: fsm.Goto("name"),
+		},
+		{
+			Name:   "name",
+			Prompt: fsm.Prompt{Key: "name.prompt"},
+			Validate: func(text string) (string, error) {
+				if strings.ContainsAny(text[:1], "0123456789") {
+					return "", &fsm.Reject{Key: "name.digit"}
+				}
+				if text == "broken" {
+					return "", errors.New("validator failed")
+				}
+				return strings.ToUpper(text), nil
+			},
+			Next: func(data fsm.Data) string {
+				if data["kind"] == "event" {
+					return "city"
+				}
+				return fsm.Finish
+			},
+		},
+		{
+			Name: "city",
+			Prompt: fsm.Prompt{Key: "city.prompt", Buttons: [][]fsm.Button{{
+				{Key: "city.skip", Value: ""},
+			}}},
+			FreeText: true,
+		},
+	},
+}
+
+func TestFlowFinishes(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", fsm.Data{"order_id": "7"}).
+		ExpectStep("kind").
+		ExpectPrompt("kind.prompt").
+		Choose("event").
+		ExpectPrompt("name.prompt").
+		Text("  party  ").
+		ExpectPrompt("city.prompt").
+		Text("Almaty").
+		ExpectDone(fsm.Data{"kind": "event", "name": "PARTY", "city": "Almaty", "order_id": "7"})
+}
+
+func TestNextSkipsSteps(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Choose("other").
+		Text("walk").
+		ExpectDone(fsm.Data{"kind": "other", "name": "WALK"})
+}
+
+func TestFreeTextStepTakesButtons(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		StartAt("test", "city", nil).
+		Choose("").
+		ExpectDone(fsm.Data{"city": ""})
+}
+
+func TestRejectsEmptyAndNonTextAnswers(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		StartAt("test", "name", nil).
+		Text("   ").
+		ExpectRejected(fsm.RejectTextRequired).
+		ExpectPrompt("name.prompt").
+		// Photos and stickers come in as messages without text.
+		Text("").
+		ExpectRejected(fsm.RejectTextRequired).
+		ExpectStep("name")
+}
+
+func TestRejectsTextOnButtonSteps(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Text("event").
+		ExpectRejected(fsm.RejectUseButtons).
+		ExpectStep("kind").
+		Choose("unknown").
+		ExpectRejected(fsm.RejectUseButtons).
+		ExpectStep("kind")
+}
+
+func TestValidation(t *testing.T) {
+	d := fsmtest.New(t, testFlow).
+		StartAt("test", "name", fsm.Data{"kind": "other"}).
+		Text("1st").
+		ExpectRejected("name.digit").
+		Text("broken").
+		ExpectRejected(fsm.RejectInvalid).
+		ExpectStep("name")
+
+	if d.Output().Data["name"] != "" {
+		t.Fatalf("rejected answer was stored: %q", d.Output().Data["name"])
+	}
+	d.Text("first").ExpectDone(fsm.Data{"name": "FIRST"})
+}
+
+func TestIgnoresStaleButtons(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Choose("event").
+		ChooseAt("kind", "other").
+		ExpectIgnored().
+		Text("party").
+		ExpectStep("city")
+}
+
+func TestBack(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Choose("event").
+		Text("party").
+		ExpectStep("city").
+		Back().
+		ExpectStep("name").
+		ExpectPrompt("name.prompt").
+		Back().
+		ExpectStep("kind").
+		Choose("other").
+		Text("walk").
+		ExpectDone(fsm.Data{"kind": "other", "name": "WALK"})
+}
+
+func TestBackOnFirstStepLeavesFlow(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Back().
+		ExpectCancelled().
+		Text("party").
+		ExpectIgnored()
+}
+
+func TestBackFromEntryStepLeavesFlow(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		StartAt("test", "name", nil).
+		Back().
+		ExpectCancelled()
+}
+
+func TestCancel(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Choose("event").
+		Cancel().
+		ExpectCancelled().
+		Text("party").
+		ExpectIgnored().
+		Cancel().
+		ExpectIgnored()
+}
+
+func TestInputWithoutFlowIsIgnored(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Text("hello").
+		ExpectIgnored().
+		Back().
+		ExpectIgnored()
+}
+
+func TestStartReplacesFlow(t *testing.T) {
+	fsmtest.New(t, testFlow).
+		Start("test", nil).
+		Choose("event").
+		Start("test", nil).
+		ExpectStep("kind").
+		Back().
+		ExpectCancelled()
+}
+
+func TestStartErrors(t *testing.T) {
+	m := fsm.New(testFlow)
+	if _, err := m.Start(1, "missing", nil); err == nil {
+		t.Fatal("started an unknown flow")
+	}
+	if _, err := m.StartAt(1, "test", "missing", nil); err == nil {
+		t.Fatal("started at an unknown step")
+	}
+	if _, _, ok := m.Active(1); ok {
+		t.Fatal("failed start left the chat in a flow")
+	}
+}
+
+func TestChatsAreIndependent(t *testing.T) {
+	m := fsm.New(testFlow)
+	if _, err := m.Start(1, "test", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.StartAt(2, "test", "name", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Handle(1, fsm.Choice("kind", "event"))
+	if _, step, _ := m.Active(1); step != "name" {
+		t.Fatalf("chat 1 is on %q, want name", step)
+	}
+	if out, _ := m.Handle(2, fsm.Text("walk")); !out.Done {
+		t.Fatalf("chat 2 did not finish: %+v", out)
+	}
+	if _, step, ok := m.Active(1); !ok || step != "name" {
+		t.Fatalf("chat 1 lost its flow: %q %v", step, ok)
+	}
+}
+
+func TestNewPanicsOnBadFlows(t *testing.T) {
+	for name, flows := range map[string][]*fsm.Flow{
+		"empty":     {{Name: "empty"}},
+		"duplicate": {testFlow, testFlow},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("New did not panic")
+				}
+			}()
+			fsm.New(flows...)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Package fsmtest drives fsm flows with fake inputs in tests:
+//
+//	fsmtest.New(t, orderFlow).
+//		Start("order", nil).
+//		ExpectPrompt("order.specialization_prompt").
+//		Choose("photographer").
+//		ExpectPrompt("order.title_prompt").
+//		Text("   ").
+//		ExpectRejected(fsm.RejectTextRequired).
+//		Text("Wedding").
+//		Back().
+//		ExpectStep("specialization")
+package fsmtest
+
+import (
+	"github.com/aidosgal/lenshub/internal/fsm"
+)
+
+// TB is the part of testing.TB the driver needs.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// chatID is the chat every driver talks in; drivers do not share machines.
+const chatID = 1
+
+// Driver feeds inputs to a machine and checks its last output.
+type Driver struct {
+	t       TB
+	machine *fsm.Machine
+	last    fsm.Output
+	handled bool
+}
+
+// New builds a driver over a fresh machine running the given flows.
+func New(t TB, flows ...*fsm.Flow) *Driver {
+	return &Driver{t: t, machine: fsm.New(flows...)}
+}
+
+// Output is the machine's answer to the last input.
+func (d *Driver) Output() fsm.Output {
+	return d.last
+}
+
+func (d *Driver) Start(flow string, data fsm.Data) *Driver {
+	d.t.Helper()
+	out, err := d.machine.Start(chatID, flow, data)
+	if err != nil {
+		d.t.Fatalf("start %q: %v", flow, err)
+	}
+	d.last, d.handled = out, true
+	return d
+}
+
+func (d *Driver) StartAt(flow, step string, data fsm.Data) *Driver {
+	d.t.Helper()
+	out, err := d.machine.StartAt(chatID, flow, step, data)
+	if err != nil {
+		d.t.Fatalf("start %q at %q: %v", flow, step, err)
+	}
+	d.last, d.handled = out, true
+	return d
+}
+
+// Text sends a typed answer.
+func (d *Driver) Text(text string) *Driver {
+	d.last, d.handled = d.machine.Handle(chatID, fsm.Text(text))
+	return d
+}
+
+// Choose presses the button with the given value on the current step.
+func (d *Driver) Choose(value string) *Driver {
+	d.t.Helper()
+	_, step, ok := d.machine.Active(chatID)
+	if !ok {
+		d.t.Fatalf("choose %q: no active flow", value)
+	}
+	return d.ChooseAt(step, value)
+}
+
+// ChooseAt presses a button that belongs to the given step, which may be a
+// stale button of an earlier step.
+func (d *Driver) ChooseAt(step, value string) *Driver {
+	d.last, d.handled = d.machine.Handle(chatID, fsm.Choice(step, value))
+	return d
+}
+
+func (d *Driver) Back() *Driver {
+	d.last, d.handled = d.machine.Back(chatID)
+	return d
+}
+
+func (d *Driver) Cancel() *Driver {
+	d.handled = d.machine.Cancel(chatID)
+	d.last = fsm.Output{Cancelled: d.handled}
+	return d
+}
+
+// ExpectPrompt checks that the machine asked the question with the given
+// message key.
+func (d *Driver) ExpectPrompt(key string) *Driver {
+	d.t.Helper()
+	if d.last.Prompt == nil {
+		d.t.Fatalf("expected prompt %q, got none (output %+v)", key, d.last)
+	}
+	if d.last.Prompt.Key != key {
+		d.t.Fatalf("expected prompt %q, got %q", key, d.last.Prompt.Key)
+	}
+	return d
+}
+
+func (d *Driver) ExpectStep(step string) *Driver {
+	d.t.Helper()
+	if d.last.Step != step {
+		d.t.Fatalf("expected step %q, got %q", step, d.last.Step)
+	}
+	return d
+}
+
+// ExpectRejected checks that the last answer was refused with the given
+// message key.
+func (d *Driver) ExpectRejected(key string) *Driver {
+	d.t.Helper()
+	if d.last.Rejected == nil {
+		d.t.Fatalf("expected rejection %q, answer was accepted", key)
+	}
+	if d.last.Rejected.Key != key {
+		d.t.Fatalf("expected rejection %q, got %q", key, d.last.Rejected.Key)
+	}
+	return d
+}
+
+// ExpectIgnored checks that the machine did nothing with the last input.
+func (d *Driver) ExpectIgnored() *Driver {
+	d.t.Helper()
+	if d.handled {
+		d.t.Fatalf("expected input to be ignored, got %+v", d.last)
+	}
+	return d
+}
+
+// ExpectDone checks that the flow finished with at least the given answers.
+func (d *Driver) ExpectDone(want fsm.Data) *Driver {
+	d.t.Helper()
+	if !d.last.Done {
+		d.t.Fatalf("expected flow to finish, got %+v", d.last)
+	}
+	d.expectData(want)
+	return d
+}
+
+func (d *Driver) ExpectCancelled() *Driver {
+	d.t.Helper()
+	if !d.last.Cancelled {
+		d.t.Fatalf("expected flow to be cancelled, got %+v", d.last)
+	}
+	return d
+}
+
+func (d *Driver) expectData(want fsm.Data) {
+	d.t.Helper()
+	for k, v := range want {
+		if got, ok := d.last.Data[k]; !ok || got != v {
+			d.t.Fatalf("expected %s = %q, got %q", k, v, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/aidosgal/lenshub/internal/config"
+	"github.com/aidosgal/lenshub/internal/lib/logger/sl"
+)
+
+// pollStaleAfter is how long the bot may go without a successful getUpdates
+// call before /readyz reports it as not ready. Long polling returns at least
+// once per poll timeout, so this leaves room for a couple of slow polls.
+const pollStaleAfter = 3 * time.Minute
+
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type Poller interface {
+	LastPoll() time.Time
+}
+
+func New(cfg config.HTTPServerConfig, db Pinger, bot Poller, metrics http.Handler, log *slog.Logger) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), cfg.Timeout)
+		defer cancel()
+
+		if err := db.PingContext(ctx); err != nil {
+			// Driver errors name the host, user and database, and the
+			// endpoint is open to anyone who can reach it.
+			log.Warn("readiness check: database unavailable", sl.Err(err))
+			http.Error(w, "database: unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		lastPoll := bot.LastPoll()
+		if lastPoll.IsZero() {
+			http.Error(w, "telegram: no successful getUpdates yet", http.StatusServiceUnavailable)
+			return
+		}
+		if since := time.Since(lastPoll); since > pollStaleAfter {
+			http.Error(w, fmt.Sprintf("telegram: last successful getUpdates %s ago", since.Round(time.Second)), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.Handle("/metrics", metrics)
+
+	return &http.Server{
+		Addr:         cfg.Address,
+		Handler:      mux,
+		ReadTimeout:  cfg.Timeout,
+		WriteTimeout: cfg.Timeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+}
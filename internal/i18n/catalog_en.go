@@ -0,0 +1,299 @@
+package i18n
+
+var en = Catalog{
+	"language.native":  {Other: "🇬🇧 English"},
+	"language.prompt":  {Other: "🌐 Choose your language:"},
+	"language.changed": {Other: "✅ Language set to English."},
+
+	"error.generic":       {Other: "❌ Something went wrong. Please try again later."},
+	"error.not_found":     {Other: "Not found. It may have been deleted."},
+	"error.invalid_state": {Other: "This action is no longer available."},
+	"error.forbidden":     {Other: "🔒 You cannot do this."},
+	"banned":              {Other: "🚫 Your account has been blocked."},
+	"callback.expired":    {Other: "⌛ This button is no longer valid. Use /start to open a fresh menu."},
+
+	"button.back":              {Other: "⬅️ Back"},
+	"button.cancel":            {Other: "✖️ Cancel"},
+	"wizard.cancelled":         {Other: "Cancelled."},
+	"wizard.nothing_to_cancel": {Other: "Nothing to cancel. Use /start to open your profile."},
+	"button.skip":              {Other: "⏭ Skip"},
+	"input.text_required":      {Other: "✍️ Please answer with a text message."},
+	"input.use_buttons":        {Other: "👇 Please choose one of the buttons."},
+	"input.invalid":            {Other: "❌ This answer doesn't fit. Please try again."},
+	"input.too_short":          {Other: "✏️ Too short, please use at least %d characters."},
+	"input.too_long":           {Other: "✂️ Too long: the limit is %d characters, you sent %d. Please shorten it."},
+	"input.links":              {Other: "🔗 Links and contacts aren't allowed here, please describe it in words. The executor will contact you after responding."},
+	"input.profanity":          {Other: "🚫 Please rephrase without obscene language."},
+
+	"role.customer": {Other: "Customer"},
+	"role.executor": {Other: "Creator"},
+
+	"role.switched":                 {Other: "🔁 Active role: %s."},
+	"role.required":                 {Other: "🔒 This action is only available to the %s role. Add it in your profile: /start"},
+	"authz.not_registered":          {Other: "You are not registered yet. Press /start to sign up."},
+	"authz.not_owner":               {Other: "🔒 This order belongs to another user."},
+	"authz.order_not_open":          {Other: "This order is no longer accepting responses."},
+	"authz.own_order":               {Other: "You cannot respond to your own order."},
+	"authz.specialization_mismatch": {Other: "This order is for a different specialization."},
+	"authz.not_in_team":             {Other: "You are not in a team. Create one from your profile: /start"},
+	"authz.team_role":               {Other: "🔒 Your role in the team does not allow this."},
+	"authz.slot_filled":             {Other: "👥 The order already has all the executors of your specialization it needs."},
+	"button.switch_to_customer":     {Other: "🔁 Switch to customer"},
+	"button.switch_to_executor":     {Other: "🔁 Switch to creator"},
+	"button.add_customer":           {Other: "➕ Become a customer"},
+	"button.add_executor":           {Other: "➕ Become a creator"},
+
+	"button.role_customer": {Other: "🤝 I'm a customer"},
+	"button.role_executor": {Other: "📸 I'm a creator"},
+
+	"registration.error": {Other: "❌ Registration failed. Please try again."},
+	"registration.error_support": {Other: `❌ Registration failed.
+
+Please try again or contact support.`},
+	"registration.portfolio_invalid": {Other: "❌ That doesn't look like a link. Please send a link to your portfolio, e.g. https://behance.net/yourname"},
+
+	"specialization.videographer":     {Other: "Videographer"},
+	"specialization.photographer":     {Other: "Photographer"},
+	"specialization.motion_designer":  {Other: "Motion designer"},
+	"specialization.graphic_designer": {Other: "Graphic designer"},
+
+	"button.spec_videographer":     {Other: "🎥 Videographer"},
+	"button.spec_photographer":     {Other: "📸 Photographer"},
+	"button.spec_motion_designer":  {Other: "🖌️ Motion designer"},
+	"button.spec_graphic_designer": {Other: "🎨 Graphic designer"},
+
+	"button.create_order":      {Other: "📝 Create order"},
+	"button.my_orders":         {Other: "📋 My orders"},
+	"button.my_portfolio":      {Other: "🎨 My portfolio"},
+	"button.edit_cities":       {Other: "🏙 My cities"},
+	"button.share_profile":     {Other: "📤 Share profile"},
+	"button.executor_profile":  {Other: "👤 Profile"},
+	"button.hire":              {Other: "🤝 Hire"},
+	"button.open_profile":      {Other: "👤 Open in LensHub"},
+	"button.respond_in_bot":    {Other: "📨 Respond in LensHub"},
+	"button.share_order":       {Other: "📢 Share order"},
+	"button.add_to_group":      {Other: "👥 Add to team group"},
+	"button.team":              {Other: "👥 My team"},
+	"button.team_profile":      {Other: "👥 Team"},
+	"button.team_invite":       {Other: "📨 Invite members"},
+	"button.team_disband":      {Other: "🗑 Disband team"},
+	"button.team_leave":        {Other: "🚪 Leave team"},
+	"button.team_join":         {Other: "✅ Join team"},
+	"button.team_make_manager": {Other: "⭐ Make manager"},
+	"button.team_make_member":  {Other: "👤 Make member"},
+	"button.team_remove":       {Other: "🚫 Remove from team"},
+	"profile.no_rating":        {Other: "no reviews yet"},
+	"profile.no_cities":        {Other: "not specified"},
+	"profile.cities_prompt":    {Other: "🏙 Which cities do you work in? List them separated by commas, up to 5.\n\nFor example: Almaty, Astana"},
+	"profile.cities_saved":     {Other: "✅ Your cities have been saved."},
+	"profile.too_many_cities":  {Other: "⚠️ Please list at most %d cities."},
+	"invite.hint":              {Other: "📨 Invite this creator to one of your open orders:"},
+	"invite.notification":      {Other: "📨 %s invites you to their order."},
+	"invite.sent":              {Other: "✅ %s has received your invitation."},
+	"hire.done":                {Other: "🤝 You hired %s for \"%s\". Close the order once the work is done to leave a review."},
+	"crew.screen":              {Other: "👥 Who does the order need? Add executors of other specializations to gather a crew.\n\nNow: %s"},
+	"crew.staffed":             {Other: "👥 The crew for \"%s\" is complete. The order no longer takes responses."},
+	"hire.notification":        {Other: "🤝 The customer chose you for the order \"%s\". Congratulations!"},
+	"group.admins_only":        {Other: "⚠️ Only group admins can do this."},
+	"group.linked":             {Other: "✅ This group is linked to %s. New orders will come here, and the account or its team can respond from here. Use /unlink to stop."},
+	"group.unlinked":           {Other: "✅ This group is no longer linked. Orders will not come here anymore."},
+	"group.not_linked":         {Other: "ℹ️ This group is not linked to an account. A group admin with a creator account can link it with /link."},
+	"group.members_only":       {Other: "⛔ %s, only the account this group is linked to and its team can respond here."},
+	"group.responded":          {Other: "✅ %s responded as %s to \"%s\". The customer has received the profile."},
+	"team_role.owner":          {Other: "owner"},
+	"team_role.manager":        {Other: "manager"},
+	"team_role.member":         {Other: "member"},
+	"team.name_prompt":         {Other: "👥 What is your team called? This is the name customers will see, e.g. the name of your studio."},
+	"team.member_row":          {Other: "• <b>%s</b> · %s · %s"},
+	"team.invite_link":         {Other: "📨 Invite link for new members:\n%s"},
+	"team.invite_text":         {Other: "Join %s on LensHub"},
+	"team.already_member":      {Other: "You are already in a team. Leave it first to create or join another one."},
+	"team.created":             {Other: "✅ The team \"%s\" has been created. Share the invite link with your colleagues."},
+	"team.join_prompt":         {Other: "📨 You have been invited to join \"%s\"."},
+	"team.joined":              {Other: "✅ You have joined \"%s\"."},
+	"team.member_joined":       {Other: "👥 %s has joined \"%s\"."},
+	"team.member":              {Other: "👤 <b>%s</b>\n🎯 %s\n👥 Role: %s"},
+	"team.role_changed":        {Other: "👥 Your role in \"%s\" is now: %s."},
+	"team.removed":             {Other: "👥 You are no longer a member of \"%s\"."},
+	"team.member_removed":      {Other: "✅ %s has been removed from the team."},
+	"team.left":                {Other: "✅ You have left \"%s\"."},
+	"team.member_left":         {Other: "👥 %s has left \"%s\"."},
+	"team.disbanded":           {Other: "👥 The team \"%s\" has been disbanded."},
+	"team.has_open_orders":     {Other: "🔒 The team still has open orders it was hired for. Close them with their customers before disbanding the team."},
+	"team.assign_prompt":       {Other: "👥 Your team was hired for \"%s\". Who will do the job?"},
+	"team.assign_done":         {Other: "✅ \"%s\" is assigned to %s."},
+	"team.assigned":            {Other: "👥 %s assigned you the order \"%s\".\n📍 %s"},
+	"team.responded_as": {
+		One:   "👥 On behalf of the team <b>%s</b> (%d member)",
+		Other: "👥 On behalf of the team <b>%s</b> (%d members)",
+	},
+	"review.prompt":        {Other: "⭐ How did the creator do on \"%s\"? Rate the work from 1 to 5."},
+	"review.prompt_member": {Other: "⭐ How did %s do on \"%s\"? Rate their work from 1 to 5."},
+	"review.duplicate":     {Other: "You have already rated this order."},
+	"review.thanks":        {Other: "🙏 Thank you for your review!"},
+	"profile.rating": {
+		One:   "%.1f ⭐ (%d review)",
+		Other: "%.1f ⭐ (%d reviews)",
+	},
+	"button.invite": {Other: "📨 Invite friends"},
+	"invite.text":   {Other: "Find photographers, videographers and designers for your projects"},
+
+	"order.specialization_prompt": {Other: `🎯 Which specialist do you need for this order?
+
+- Videographer - video shooting and editing
+- Photographer - photo shooting and retouching`},
+	"order.title_prompt": {Other: `📝 Great! Now enter a title for the order:
+For example: "Wedding photo shoot" or "Birthday party video"`},
+	"order.description_prompt": {Other: `📝 Great! Now describe the order in detail:
+
+For example:
+- What exactly needs to be done
+- When the shoot is planned
+- Any special wishes or requirements`},
+	"order.location_prompt": {Other: `📍 Where will the shoot take place?
+
+For example: "Almaty, Gorky Park" or "Studio at 150 Abay Ave"`},
+	"order.create_error": {Other: "❌ Failed to create the order. Please try again."},
+	"order.executors_notified": {
+		One:   "📣 %d creator has been notified about your order.",
+		Other: "📣 %d creators have been notified about your order.",
+	},
+	"order.no_executors": {Other: "📣 There are no creators with this specialization yet. We will tell them about your order as soon as they join."},
+	"order.card": {Other: `%s
+
+📋 <b>%s</b>
+🎯 %s
+📝 %s
+📍 %s`},
+	"order_status.draft":           {Other: "📝 Draft — check the order and publish it"},
+	"order_status.pending_review":  {Other: "⏳ Under review"},
+	"order_status.open":            {Other: "✅ Published"},
+	"order_status.closed":          {Other: "🔒 Closed"},
+	"order_status.rejected":        {Other: "❌ Rejected by a moderator"},
+	"order_status.cancelled":       {Other: "🚫 Cancelled"},
+	"order_status.staffed":         {Other: "👥 Crew complete"},
+	"button.edit_title":            {Other: "✏️ Edit title"},
+	"button.edit_description":      {Other: "✏️ Edit description"},
+	"button.edit_location":         {Other: "✏️ Edit location"},
+	"button.publish":               {Other: "🚀 Publish"},
+	"button.close_order":           {Other: "🔒 Close order"},
+	"button.cancel_order":          {Other: "🚫 Cancel order"},
+	"button.crew":                  {Other: "👥 Crew"},
+	"button.repost":                {Other: "🔁 Post again"},
+	"order.list":                   {Other: "📋 Your orders:"},
+	"order.list_empty":             {Other: "You have no orders yet."},
+	"order.update_error":           {Other: "❌ Failed to save the changes. The order may already be closed."},
+	"order.updated":                {Other: "✅ Changes saved."},
+	"order.updated_pending_review": {Other: "⏳ Changes saved. A moderator will check them before executors and channels see the new version."},
+	"order.closed":                 {Other: "🔒 The order is closed. You can post it again at any time."},
+	"order.cancelled":              {Other: "🚫 The order is cancelled and has been removed from the channels. You can post it again at any time."},
+	"channel.order_closed":         {Other: "🔒 <b>This order is closed.</b>"},
+	"button.respond":               {Other: "✅ Respond"},
+	"button.report":                {Other: "⚠️ Report"},
+
+	"response.save_error":    {Other: "❌ Failed to save your response. Please try again later."},
+	"response.note_prompt":   {Other: "💬 Add a short note for the customer: your price, experience or availability. Or skip this step."},
+	"response.note_too_long": {Other: "❌ The note is too long, please keep it within %d characters."},
+	"response.confirmed": {Other: `✅ You have responded to the order!
+
+The customer will receive your profile and contact you on Telegram.`},
+	"button.executor_portfolio": {Other: "🎨 Creator's portfolio"},
+
+	"report.error":     {Other: "❌ Failed to send the report. Please try again later."},
+	"report.duplicate": {Other: "You have already reported this. Moderators will review it."},
+	"report.self":      {Other: "You cannot report yourself."},
+	"report.sent":      {Other: "✅ Thank you! Your report has been sent to moderators."},
+
+	"admin.yes":         {Other: "yes"},
+	"admin.no":          {Other: "no"},
+	"admin.audit_error": {Other: "❌ Failed to write the audit log. The command was not executed."},
+	"admin.stats_error": {Other: "❌ Failed to get statistics."},
+	"admin.stats": {Other: `📊 Statistics
+
+👥 Customers: %d
+📸 Creators: %d
+🚫 Blocked: %d
+
+📋 Open orders: %d
+📦 Total orders: %d
+🆕 Orders today: %d
+✅ Responses: %d`},
+	"admin.user_usage":     {Other: "Usage: /user <chat_id>"},
+	"admin.user_error":     {Other: "❌ Failed to get the user."},
+	"admin.user_not_found": {Other: "User not found."},
+	"admin.not_found":      {Other: "🔍 Nothing found. Check the ID."},
+	"admin.invalid_state":  {Other: "⚠️ Not possible right now, e.g. the order is already closed or the report already resolved."},
+	"admin.user": {Other: `👤 User #%d
+
+Chat ID: %s
+Name: %s
+Username: @%s
+Role: %s
+Specialization: %s
+Portfolio: %s
+Blocked: %s`},
+	"admin.ban_usage":   {Other: "Usage: /ban <chat_id> or /unban <chat_id>"},
+	"admin.ban_error":   {Other: "❌ Failed to change the user's status."},
+	"admin.banned":      {Other: "🚫 User %s has been blocked."},
+	"admin.unbanned":    {Other: "✅ User %s has been unblocked."},
+	"admin.order_usage": {Other: "Usage: /order <id>"},
+	"admin.order_error": {Other: "❌ Failed to get the order."},
+	"admin.order": {Other: `📋 Order #%d
+
+Status: %s
+Title: %s
+Specialization: %s
+Description: %s
+Location: %s
+Created: %s
+
+Customer: %s (@%s), chat ID %s`},
+	"admin.close_order_usage": {Other: "Usage: /close_order <id>"},
+	"admin.close_order_error": {Other: "❌ Failed to close the order."},
+	"admin.order_closed":      {Other: "✅ Order #%s has been closed."},
+	"admin.broadcast_usage":   {Other: "Usage: /broadcast <text>"},
+	"admin.broadcast_error":   {Other: "❌ Failed to get the list of recipients."},
+	"admin.broadcast_started": {
+		One:   "📣 Broadcast started: %d recipient.",
+		Other: "📣 Broadcast started: %d recipients.",
+	},
+	"admin.broadcast_finished": {Other: "📣 Broadcast finished: delivered %d of %d."},
+
+	"admin.reports_error": {Other: "❌ Failed to get reports."},
+	"admin.reports_empty": {Other: "✅ No new reports."},
+	"admin.report_card": {Other: `⚠️ Report #%d from %s
+
+Reported: %s (@%s), %s
+Chat ID: %s
+Order: %s
+
+Reporter: %s (@%s), chat ID %s`},
+	"button.ban":                 {Other: "🚫 Block"},
+	"button.dismiss":             {Other: "✖️ Dismiss"},
+	"admin.report_error":         {Other: "❌ Failed to get the report."},
+	"admin.report_resolve_error": {Other: "❌ Failed to resolve the report."},
+	"admin.report_banned":        {Other: "🚫 Report #%d: user %s has been blocked."},
+	"admin.report_dismissed":     {Other: "✖️ Report #%d dismissed."},
+
+	"admin.moderation_error":  {Other: "❌ Failed to get orders awaiting review."},
+	"admin.moderation_empty":  {Other: "✅ No orders awaiting review."},
+	"admin.moderation_edited": {Other: "✏️ Edited by the customer. Review this version of order #%d, earlier cards show an outdated text."},
+	"admin.moderation_card": {Other: `🛡 Order #%d is awaiting review
+
+📋 %s
+🎯 Specialization: %s
+📝 %s
+📍 %s
+🕒 %s
+
+Customer: %s (@%s), chat ID %s`},
+	"button.approve":          {Other: "✅ Approve"},
+	"button.reject":           {Other: "❌ Reject"},
+	"admin.moderation_failed": {Other: "❌ Failed to process order #%s."},
+	"admin.order_approved":    {Other: "✅ Order #%s approved and sent to creators."},
+	"admin.order_rejected":    {Other: "❌ Order #%s rejected."},
+	"admin.links_error":       {Other: "❌ Failed to get link statistics."},
+	"admin.links_empty":       {Other: "No one has opened the bot through a link yet."},
+	"admin.links":             {Other: "🔗 Start links"},
+	"admin.links_row":         {Other: "<code>%s</code>: %d visits, %d chats, %d sign-ups"},
+}
@@ -0,0 +1,295 @@
+package i18n
+
+var kk = Catalog{
+	"language.native":  {Other: "🇰🇿 Қазақша"},
+	"language.prompt":  {Other: "🌐 Интерфейс тілін таңдаңыз:"},
+	"language.changed": {Other: "✅ Интерфейс тілі: қазақша."},
+
+	"error.generic":       {Other: "❌ Қате орын алды. Кейінірек қайталап көріңіз."},
+	"error.not_found":     {Other: "Табылмады. Мүмкін, ол жойылған."},
+	"error.invalid_state": {Other: "Бұл әрекет енді қолжетімсіз."},
+	"error.forbidden":     {Other: "🔒 Бұл әрекетке рұқсатыңыз жоқ."},
+	"banned":              {Other: "🚫 Сіздің аккаунтыңыз бұғатталған."},
+	"callback.expired":    {Other: "⌛ Бұл батырма енді жарамсыз. Жаңа мәзірді /start арқылы ашыңыз."},
+
+	"button.back":              {Other: "⬅️ Артқа"},
+	"button.cancel":            {Other: "✖️ Болдырмау"},
+	"wizard.cancelled":         {Other: "Әрекет тоқтатылды."},
+	"wizard.nothing_to_cancel": {Other: "Тоқтататын ештеңе жоқ. Профильді ашу үшін /start пайдаланыңыз."},
+	"button.skip":              {Other: "⏭ Өткізіп жіберу"},
+	"input.text_required":      {Other: "✍️ Мәтіндік хабарламамен жауап беріңіз."},
+	"input.use_buttons":        {Other: "👇 Батырмалардың бірін таңдаңыз."},
+	"input.invalid":            {Other: "❌ Бұл жауап жарамайды. Қайталап көріңіз."},
+	"input.too_short":          {Other: "✏️ Тым қысқа, кемінде %d таңба жазыңыз."},
+	"input.too_long":           {Other: "✂️ Тым ұзын: шегі %d таңба, сізде %d. Қысқартыңыз."},
+	"input.links":              {Other: "🔗 Мұнда сілтемелер мен байланыс деректерін жазуға болмайды, сөзбен сипаттаңыз. Орындаушы өтінім бергеннен кейін сізбен байланысады."},
+	"input.profanity":          {Other: "🚫 Әдепсіз сөздерсіз қайта жазыңыз."},
+
+	"role.customer": {Other: "Тапсырыс беруші"},
+	"role.executor": {Other: "Орындаушы"},
+
+	"role.switched":                 {Other: "🔁 Белсенді рөл: %s."},
+	"role.required":                 {Other: "🔒 Бұл әрекет тек «%s» рөлінде қолжетімді. Оны профильде қосыңыз: /start"},
+	"authz.not_registered":          {Other: "Сіз әлі тіркелмегенсіз. Бастау үшін /start басыңыз."},
+	"authz.not_owner":               {Other: "🔒 Бұл тапсырыс басқа пайдаланушыға тиесілі."},
+	"authz.order_not_open":          {Other: "Бұл тапсырыс енді жауаптарды қабылдамайды."},
+	"authz.own_order":               {Other: "Өз тапсырысыңызға жауап беруге болмайды."},
+	"authz.specialization_mismatch": {Other: "Бұл тапсырыс басқа мамандыққа арналған."},
+	"authz.not_in_team":             {Other: "Сіз командада емессіз. Оны профильде құруға болады: /start"},
+	"authz.team_role":               {Other: "🔒 Командадағы рөліңіз бұған рұқсат бермейді."},
+	"authz.slot_filled":             {Other: "👥 Тапсырысқа сіздің мамандығыңыз бойынша орындаушылардың бәрі табылды."},
+	"button.switch_to_customer":     {Other: "🔁 Тапсырыс беруші рөліне ауысу"},
+	"button.switch_to_executor":     {Other: "🔁 Орындаушы рөліне ауысу"},
+	"button.add_customer":           {Other: "➕ Тапсырыс беруші болу"},
+	"button.add_executor":           {Other: "➕ Орындаушы болу"},
+
+	"button.role_customer": {Other: "🤝 Мен тапсырыс берушімін"},
+	"button.role_executor": {Other: "📸 Мен орындаушымын"},
+
+	"registration.error": {Other: "❌ Тіркелу кезінде қате орын алды. Қайталап көріңіз."},
+	"registration.error_support": {Other: `❌ Тіркелу кезінде қате орын алды.
+
+Қайталап көріңіз немесе қолдау қызметіне жазыңыз.`},
+	"registration.portfolio_invalid": {Other: "❌ Бұл сілтемеге ұқсамайды. Портфолиоңызға сілтеме жіберіңіз, мысалы https://behance.net/yourname"},
+
+	"specialization.videographer":     {Other: "Видеооператор"},
+	"specialization.photographer":     {Other: "Фотограф"},
+	"specialization.motion_designer":  {Other: "Motion дизайнер"},
+	"specialization.graphic_designer": {Other: "Графикалық дизайнер"},
+
+	"button.spec_videographer":     {Other: "🎥 Видеооператор"},
+	"button.spec_photographer":     {Other: "📸 Фотограф"},
+	"button.spec_motion_designer":  {Other: "🖌️ Motion дизайнер"},
+	"button.spec_graphic_designer": {Other: "🎨 Графикалық дизайнер"},
+
+	"button.create_order":      {Other: "📝 Тапсырыс жасау"},
+	"button.my_orders":         {Other: "📋 Менің тапсырыстарым"},
+	"button.my_portfolio":      {Other: "🎨 Менің портфолиом"},
+	"button.edit_cities":       {Other: "🏙 Менің қалаларым"},
+	"button.share_profile":     {Other: "📤 Профильмен бөлісу"},
+	"button.executor_profile":  {Other: "👤 Профиль"},
+	"button.hire":              {Other: "🤝 Орындаушы ретінде таңдау"},
+	"button.open_profile":      {Other: "👤 LensHub-та ашу"},
+	"button.respond_in_bot":    {Other: "📨 LensHub-та жауап беру"},
+	"button.share_order":       {Other: "📢 Тапсырыспен бөлісу"},
+	"button.add_to_group":      {Other: "👥 Команда тобына қосу"},
+	"button.team":              {Other: "👥 Менің командам"},
+	"button.team_profile":      {Other: "👥 Команда"},
+	"button.team_invite":       {Other: "📨 Қатысушыларды шақыру"},
+	"button.team_disband":      {Other: "🗑 Команданы тарату"},
+	"button.team_leave":        {Other: "🚪 Командадан шығу"},
+	"button.team_join":         {Other: "✅ Командаға қосылу"},
+	"button.team_make_manager": {Other: "⭐ Менеджер ету"},
+	"button.team_make_member":  {Other: "👤 Қатысушы ету"},
+	"button.team_remove":       {Other: "🚫 Командадан шығару"},
+	"profile.no_rating":        {Other: "әзірге пікір жоқ"},
+	"profile.no_cities":        {Other: "көрсетілмеген"},
+	"profile.cities_prompt":    {Other: "🏙 Қай қалаларда жұмыс істейсіз? Үтір арқылы жазыңыз, 5-тен аспасын.\n\nМысалы: Алматы, Астана"},
+	"profile.cities_saved":     {Other: "✅ Қалалар сақталды."},
+	"profile.too_many_cities":  {Other: "⚠️ %d қаладан артық көрсетпеңіз."},
+	"invite.hint":              {Other: "📨 Орындаушыны ашық тапсырыстарыңыздың біріне шақырыңыз:"},
+	"invite.notification":      {Other: "📨 %s сізді өз тапсырысына шақырады."},
+	"invite.sent":              {Other: "✅ Шақыру жіберілді: %s."},
+	"hire.done":                {Other: "🤝 Сіз %s орындаушысын «%s» тапсырысына таңдадыңыз. Пікір қалдыру үшін жұмыс біткен соң тапсырысты жабыңыз."},
+	"crew.screen":              {Other: "👥 Тапсырысқа кім керек? Топ жинау үшін басқа мамандықтағы орындаушыларды қосыңыз.\n\nҚазір: %s"},
+	"crew.staffed":             {Other: "👥 «%s» тапсырысының тобы жиналды. Тапсырысқа енді өтінім қабылданбайды."},
+	"hire.notification":        {Other: "🤝 Тапсырыс беруші сізді «%s» тапсырысының орындаушысы етіп таңдады. Құттықтаймыз!"},
+	"group.admins_only":        {Other: "⚠️ Мұны тек топ әкімшілері жасай алады."},
+	"group.linked":             {Other: "✅ Топ %s аккаунтына байланыстырылды. Жаңа тапсырыстар осында келеді, аккаунт пен оның командасы осы жерден жауап бере алады. Ажырату: /unlink."},
+	"group.unlinked":           {Other: "✅ Топ ажыратылды. Тапсырыстар бұдан былай мұнда келмейді."},
+	"group.not_linked":         {Other: "ℹ️ Топ аккаунтқа байланыстырылмаған. Орындаушы аккаунты бар топ әкімшісі оны /link командасымен байланыстыра алады."},
+	"group.members_only":       {Other: "⛔ %s, мұнда тек байланыстырылған аккаунт пен оның командасы жауап бере алады."},
+	"group.responded":          {Other: "✅ %s \"%[3]s\" тапсырысына %[2]s атынан жауап берді. Тапсырыс беруші профильді алды."},
+	"team_role.owner":          {Other: "иесі"},
+	"team_role.manager":        {Other: "менеджер"},
+	"team_role.member":         {Other: "қатысушы"},
+	"team.name_prompt":         {Other: "👥 Командаңыз қалай аталады? Бұл атауды тапсырыс берушілер көреді, мысалы студияңыздың атауы."},
+	"team.member_row":          {Other: "• <b>%s</b> · %s · %s"},
+	"team.invite_link":         {Other: "📨 Жаңа қатысушыларға арналған шақыру сілтемесі:\n%s"},
+	"team.invite_text":         {Other: "LensHub-тағы %s командасына қосылыңыз"},
+	"team.already_member":      {Other: "Сіз командадасыз. Басқасын құру немесе басқасына қосылу үшін алдымен одан шығыңыз."},
+	"team.created":             {Other: "✅ «%s» командасы құрылды. Шақыру сілтемесін әріптестеріңізге жіберіңіз."},
+	"team.join_prompt":         {Other: "📨 Сізді «%s» командасына шақырады."},
+	"team.joined":              {Other: "✅ Сіз «%s» командасына қосылдыңыз."},
+	"team.member_joined":       {Other: "👥 %s «%s» командасына қосылды."},
+	"team.member":              {Other: "👤 <b>%s</b>\n🎯 %s\n👥 Рөлі: %s"},
+	"team.role_changed":        {Other: "👥 «%s» командасындағы рөліңіз енді: %s."},
+	"team.removed":             {Other: "👥 Сіз енді «%s» командасында емессіз."},
+	"team.member_removed":      {Other: "✅ %s командадан шығарылды."},
+	"team.left":                {Other: "✅ Сіз «%s» командасынан шықтыңыз."},
+	"team.member_left":         {Other: "👥 %s «%s» командасынан шықты."},
+	"team.disbanded":           {Other: "👥 «%s» командасы таратылды."},
+	"team.has_open_orders":     {Other: "🔒 Команданы жалдаған ашық тапсырыстар әлі бар. Команданы таратпас бұрын оларды тапсырыс берушілермен бірге аяқтаңыз."},
+	"team.assign_prompt":       {Other: "👥 Командаңыз «%s» тапсырысына таңдалды. Оны кім орындайды?"},
+	"team.assign_done":         {Other: "✅ «%s» тапсырысы тапсырылды: %s."},
+	"team.assigned":            {Other: "👥 %s сізге «%s» тапсырысын тапсырды.\n📍 %s"},
+	"team.responded_as": {
+		Other: "👥 <b>%s</b> командасы атынан (%d қатысушы)",
+	},
+	"review.prompt":        {Other: "⭐ Орындаушы «%s» тапсырысын қалай орындады? Жұмысты 1-ден 5-ке дейін бағалаңыз."},
+	"review.prompt_member": {Other: "⭐ %s «%s» тапсырысын қалай орындады? Жұмысты 1-ден 5-ке дейін бағалаңыз."},
+	"review.duplicate":     {Other: "Сіз бұл тапсырысты бағалап қойдыңыз."},
+	"review.thanks":        {Other: "🙏 Пікіріңізге рахмет!"},
+	"profile.rating": {
+		Other: "%.1f ⭐ (%d пікір)",
+	},
+	"button.invite": {Other: "📨 Достарды шақыру"},
+	"invite.text":   {Other: "Жобаларыңызға фотографтарды, видеографтарды және дизайнерлерді табыңыз"},
+
+	"order.specialization_prompt": {Other: `🎯 Тапсырысыңызға қандай маман керек?
+
+- Видеооператор - бейне түсіру және монтаж
+- Фотограф - фото түсіру және өңдеу`},
+	"order.title_prompt": {Other: `📝 Керемет! Енді тапсырыстың атауын енгізіңіз:
+Мысалы: "Той фотосессиясы" немесе "Туған күнді бейнеге түсіру"`},
+	"order.description_prompt": {Other: `📝 Керемет! Енді тапсырысты толығырақ сипаттаңыз:
+
+Мысалы:
+- Нақты не істеу керек
+- Түсірілім қашан жоспарланған
+- Ерекше тілектер немесе талаптар`},
+	"order.location_prompt": {Other: `📍 Түсірілім қай жерде өтетінін көрсетіңіз:
+
+Мысалы: "Алматы, Горький саябағы" немесе "Абай 150, студия"`},
+	"order.create_error": {Other: "❌ Тапсырыс жасау кезінде қате орын алды. Қайталап көріңіз."},
+	"order.executors_notified": {
+		Other: "📣 Тапсырыс туралы хабарламаны %d орындаушы алды.",
+	},
+	"order.no_executors": {Other: "📣 Әзірге қажетті мамандығы бар орындаушылар жоқ. Олар пайда болған бойда тапсырыс туралы хабарлаймыз."},
+	"order.card": {Other: `%s
+
+📋 <b>%s</b>
+🎯 %s
+📝 %s
+📍 %s`},
+	"order_status.draft":           {Other: "📝 Жоба — тапсырысты тексеріп, жариялаңыз"},
+	"order_status.pending_review":  {Other: "⏳ Модерацияда"},
+	"order_status.open":            {Other: "✅ Жарияланған"},
+	"order_status.closed":          {Other: "🔒 Жабық"},
+	"order_status.rejected":        {Other: "❌ Модератор қабылдамады"},
+	"order_status.cancelled":       {Other: "🚫 Тоқтатылды"},
+	"order_status.staffed":         {Other: "👥 Топ жиналды"},
+	"button.edit_title":            {Other: "✏️ Атауын өзгерту"},
+	"button.edit_description":      {Other: "✏️ Сипаттамасын өзгерту"},
+	"button.edit_location":         {Other: "✏️ Орнын өзгерту"},
+	"button.publish":               {Other: "🚀 Жариялау"},
+	"button.close_order":           {Other: "🔒 Тапсырысты жабу"},
+	"button.cancel_order":          {Other: "🚫 Тапсырыстан бас тарту"},
+	"button.crew":                  {Other: "👥 Топ құрамы"},
+	"button.repost":                {Other: "🔁 Қайта жариялау"},
+	"order.list":                   {Other: "📋 Сіздің тапсырыстарыңыз:"},
+	"order.list_empty":             {Other: "Сізде әзірге тапсырыстар жоқ."},
+	"order.update_error":           {Other: "❌ Өзгерістерді сақтау мүмкін болмады. Тапсырыс жабылған болуы мүмкін."},
+	"order.updated":                {Other: "✅ Өзгерістер сақталды."},
+	"order.updated_pending_review": {Other: "⏳ Өзгерістер сақталды. Орындаушылар мен арналар жаңа нұсқаны модератор тексергеннен кейін көреді."},
+	"order.closed":                 {Other: "🔒 Тапсырыс жабылды. Оны кез келген уақытта қайта жариялауға болады."},
+	"order.cancelled":              {Other: "🚫 Тапсырыс тоқтатылып, арналардан жойылды. Оны кез келген уақытта қайта жариялай аласыз."},
+	"channel.order_closed":         {Other: "🔒 <b>Тапсырыс жабылды.</b>"},
+	"button.respond":               {Other: "✅ Өтінім беру"},
+	"button.report":                {Other: "⚠️ Шағымдану"},
+
+	"response.save_error":    {Other: "❌ Өтінімді сақтау кезінде қате орын алды. Кейінірек қайталап көріңіз."},
+	"response.note_prompt":   {Other: "💬 Тапсырыс берушіге қысқа хабарлама қосыңыз: баға, тәжірибе немесе бос уақытыңыз. Немесе бұл қадамды өткізіп жіберіңіз."},
+	"response.note_too_long": {Other: "❌ Хабарлама тым ұзын, %d таңбадан аспауы керек."},
+	"response.confirmed": {Other: `✅ Сіз тапсырысқа сәтті өтінім бердіңіз!
+
+Тапсырыс беруші сіздің профиліңізді алып, Telegram арқылы сізбен байланысады.`},
+	"button.executor_portfolio": {Other: "🎨 Орындаушының портфолиосы"},
+
+	"report.error":     {Other: "❌ Шағымды жіберу мүмкін болмады. Кейінірек қайталап көріңіз."},
+	"report.duplicate": {Other: "Сіз шағымды жіберіп қойғансыз. Модераторлар оны қарастырады."},
+	"report.self":      {Other: "Өзіңізге шағымдана алмайсыз."},
+	"report.sent":      {Other: "✅ Рақмет! Шағым модераторларға жіберілді."},
+
+	"admin.yes":         {Other: "иә"},
+	"admin.no":          {Other: "жоқ"},
+	"admin.audit_error": {Other: "❌ Әрекетті аудит журналына жазу мүмкін болмады. Команда орындалмады."},
+	"admin.stats_error": {Other: "❌ Статистиканы алу мүмкін болмады."},
+	"admin.stats": {Other: `📊 Статистика
+
+👥 Тапсырыс берушілер: %d
+📸 Орындаушылар: %d
+🚫 Бұғатталғандар: %d
+
+📋 Ашық тапсырыстар: %d
+📦 Барлық тапсырыстар: %d
+🆕 Бүгінгі тапсырыстар: %d
+✅ Өтінімдер: %d`},
+	"admin.user_usage":     {Other: "Қолданылуы: /user <chat_id>"},
+	"admin.user_error":     {Other: "❌ Пайдаланушыны алу мүмкін болмады."},
+	"admin.user_not_found": {Other: "Пайдаланушы табылмады."},
+	"admin.not_found":      {Other: "🔍 Ештеңе табылмады. ID-ді тексеріңіз."},
+	"admin.invalid_state":  {Other: "⚠️ Қазір мүмкін емес: мысалы, тапсырыс жабылған немесе шағым өңделген."},
+	"admin.user": {Other: `👤 Пайдаланушы #%d
+
+Chat ID: %s
+Аты: %s
+Username: @%s
+Рөлі: %s
+Мамандығы: %s
+Портфолио: %s
+Бұғатталған: %s`},
+	"admin.ban_usage":   {Other: "Қолданылуы: /ban <chat_id> немесе /unban <chat_id>"},
+	"admin.ban_error":   {Other: "❌ Пайдаланушы күйін өзгерту мүмкін болмады."},
+	"admin.banned":      {Other: "🚫 %s пайдаланушысы бұғатталды."},
+	"admin.unbanned":    {Other: "✅ %s пайдаланушысы бұғаттан шығарылды."},
+	"admin.order_usage": {Other: "Қолданылуы: /order <id>"},
+	"admin.order_error": {Other: "❌ Тапсырысты алу мүмкін болмады."},
+	"admin.order": {Other: `📋 Тапсырыс #%d
+
+Күйі: %s
+Атауы: %s
+Мамандық: %s
+Сипаттама: %s
+Орны: %s
+Жасалған уақыты: %s
+
+Тапсырыс беруші: %s (@%s), chat ID %s`},
+	"admin.close_order_usage": {Other: "Қолданылуы: /close_order <id>"},
+	"admin.close_order_error": {Other: "❌ Тапсырысты жабу мүмкін болмады."},
+	"admin.order_closed":      {Other: "✅ #%s тапсырысы жабылды."},
+	"admin.broadcast_usage":   {Other: "Қолданылуы: /broadcast <мәтін>"},
+	"admin.broadcast_error":   {Other: "❌ Алушылар тізімін алу мүмкін болмады."},
+	"admin.broadcast_started": {
+		Other: "📣 Тарату басталды: %d алушы.",
+	},
+	"admin.broadcast_finished": {Other: "📣 Тарату аяқталды: %d / %d жеткізілді."},
+
+	"admin.reports_error": {Other: "❌ Шағымдарды алу мүмкін болмады."},
+	"admin.reports_empty": {Other: "✅ Жаңа шағымдар жоқ."},
+	"admin.report_card": {Other: `⚠️ Шағым #%d, %s
+
+Бұзушы: %s (@%s), %s
+Chat ID: %s
+Тапсырыс: %s
+
+Жіберуші: %s (@%s), chat ID %s`},
+	"button.ban":                 {Other: "🚫 Бұғаттау"},
+	"button.dismiss":             {Other: "✖️ Қабылдамау"},
+	"admin.report_error":         {Other: "❌ Шағымды алу мүмкін болмады."},
+	"admin.report_resolve_error": {Other: "❌ Шағымды өңдеу мүмкін болмады."},
+	"admin.report_banned":        {Other: "🚫 Шағым #%d: %s пайдаланушысы бұғатталды."},
+	"admin.report_dismissed":     {Other: "✖️ Шағым #%d қабылданбады."},
+
+	"admin.moderation_error":  {Other: "❌ Модерациядағы тапсырыстарды алу мүмкін болмады."},
+	"admin.moderation_empty":  {Other: "✅ Модерациядағы тапсырыстар жоқ."},
+	"admin.moderation_edited": {Other: "✏️ Тапсырыс беруші өзгертті. #%d тапсырысының осы нұсқасын тексеріңіз, бұрынғы карточкаларда ескі мәтін."},
+	"admin.moderation_card": {Other: `🛡 #%d тапсырысы модерацияны күтуде
+
+📋 %s
+🎯 Мамандық: %s
+📝 %s
+📍 %s
+🕒 %s
+
+Тапсырыс беруші: %s (@%s), chat ID %s`},
+	"button.approve":          {Other: "✅ Мақұлдау"},
+	"button.reject":           {Other: "❌ Қабылдамау"},
+	"admin.moderation_failed": {Other: "❌ #%s тапсырысын өңдеу мүмкін болмады."},
+	"admin.order_approved":    {Other: "✅ #%s тапсырысы мақұлданып, орындаушыларға жіберілді."},
+	"admin.order_rejected":    {Other: "❌ #%s тапсырысы қабылданбады."},
+	"admin.links_error":       {Other: "❌ Сілтемелер статистикасын алу мүмкін болмады."},
+	"admin.links_empty":       {Other: "Ботты сілтеме арқылы әлі ешкім ашқан жоқ."},
+	"admin.links":             {Other: "🔗 Бастапқы сілтемелер"},
+	"admin.links_row":         {Other: "<code>%s</code>: өтулер %d, чаттар %d, тіркелулер %d"},
+}
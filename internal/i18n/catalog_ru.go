@@ -0,0 +1,305 @@
+package i18n
+
+var ru = Catalog{
+	"language.native":  {Other: "🇷🇺 Русский"},
+	"language.prompt":  {Other: "🌐 Выберите язык интерфейса:"},
+	"language.changed": {Other: "✅ Язык интерфейса: русский."},
+
+	"error.generic":       {Other: "❌ Произошла ошибка. Пожалуйста, попробуйте позже."},
+	"error.not_found":     {Other: "Не найдено. Возможно, оно было удалено."},
+	"error.invalid_state": {Other: "Это действие больше недоступно."},
+	"error.forbidden":     {Other: "🔒 У вас нет доступа к этому действию."},
+	"banned":              {Other: "🚫 Ваш аккаунт заблокирован."},
+	"callback.expired":    {Other: "⌛ Эта кнопка больше не действует. Откройте актуальное меню через /start."},
+
+	"button.back":              {Other: "⬅️ Назад"},
+	"button.cancel":            {Other: "✖️ Отмена"},
+	"wizard.cancelled":         {Other: "Действие отменено."},
+	"wizard.nothing_to_cancel": {Other: "Нечего отменять. Используйте /start, чтобы открыть профиль."},
+	"button.skip":              {Other: "⏭ Пропустить"},
+	"input.text_required":      {Other: "✍️ Пожалуйста, ответьте текстовым сообщением."},
+	"input.use_buttons":        {Other: "👇 Пожалуйста, выберите один из вариантов на кнопках."},
+	"input.invalid":            {Other: "❌ Такой ответ не подходит. Попробуйте ещё раз."},
+	"input.too_short":          {Other: "✏️ Слишком коротко, нужно хотя бы %d символа."},
+	"input.too_long":           {Other: "✂️ Слишком длинно: можно до %d символов, у вас %d. Сократите, пожалуйста."},
+	"input.links":              {Other: "🔗 Ссылки и контакты здесь не допускаются, опишите словами. Исполнитель свяжется с вами после отклика."},
+	"input.profanity":          {Other: "🚫 Пожалуйста, переформулируйте без нецензурной лексики."},
+
+	"role.customer": {Other: "Заказчик"},
+	"role.executor": {Other: "Исполнитель"},
+
+	"role.switched":                 {Other: "🔁 Активная роль: %s."},
+	"role.required":                 {Other: "🔒 Это действие доступно только в роли «%s». Добавьте её в профиле: /start"},
+	"authz.not_registered":          {Other: "Вы ещё не зарегистрированы. Нажмите /start, чтобы начать."},
+	"authz.not_owner":               {Other: "🔒 Этот заказ принадлежит другому пользователю."},
+	"authz.order_not_open":          {Other: "Заказ больше не принимает отклики."},
+	"authz.own_order":               {Other: "Нельзя откликнуться на собственный заказ."},
+	"authz.specialization_mismatch": {Other: "Этот заказ для другой специализации."},
+	"authz.not_in_team":             {Other: "Вы не состоите в команде. Создать её можно в профиле: /start"},
+	"authz.team_role":               {Other: "🔒 Ваша роль в команде не позволяет это сделать."},
+	"authz.slot_filled":             {Other: "👥 В заказе уже набраны все исполнители вашей специализации."},
+	"button.switch_to_customer":     {Other: "🔁 Перейти в роль заказчика"},
+	"button.switch_to_executor":     {Other: "🔁 Перейти в роль исполнителя"},
+	"button.add_customer":           {Other: "➕ Стать заказчиком"},
+	"button.add_executor":           {Other: "➕ Стать исполнителем"},
+
+	"button.role_customer": {Other: "🤝 Я заказчик"},
+	"button.role_executor": {Other: "📸 Я исполнитель"},
+
+	"registration.error": {Other: "❌ Произошла ошибка при регистрации. Пожалуйста, попробуйте еще раз."},
+	"registration.error_support": {Other: `❌ Произошла ошибка при регистрации.
+
+Пожалуйста, попробуйте еще раз или свяжитесь с поддержкой.`},
+	"registration.portfolio_invalid": {Other: "❌ Это не похоже на ссылку. Отправьте ссылку на портфолио, например https://behance.net/yourname"},
+
+	"specialization.videographer":     {Other: "Видеооператор"},
+	"specialization.photographer":     {Other: "Фотограф"},
+	"specialization.motion_designer":  {Other: "Motion Дизайнер"},
+	"specialization.graphic_designer": {Other: "Графический Дизайнер"},
+
+	"button.spec_videographer":     {Other: "🎥 Видеооператор"},
+	"button.spec_photographer":     {Other: "📸 Фотограф"},
+	"button.spec_motion_designer":  {Other: "🖌️ Motion Дизайнер"},
+	"button.spec_graphic_designer": {Other: "🎨 Графический Дизайнер"},
+
+	"button.create_order":      {Other: "📝 Создать заказ"},
+	"button.my_orders":         {Other: "📋 Мои заказы"},
+	"button.my_portfolio":      {Other: "🎨 Моё портфолио"},
+	"button.edit_cities":       {Other: "🏙 Мои города"},
+	"button.share_profile":     {Other: "📤 Поделиться профилем"},
+	"button.executor_profile":  {Other: "👤 Профиль"},
+	"button.hire":              {Other: "🤝 Выбрать исполнителем"},
+	"button.open_profile":      {Other: "👤 Открыть в LensHub"},
+	"button.respond_in_bot":    {Other: "📨 Откликнуться в LensHub"},
+	"button.share_order":       {Other: "📢 Поделиться заказом"},
+	"button.add_to_group":      {Other: "👥 Добавить в группу команды"},
+	"button.team":              {Other: "👥 Моя команда"},
+	"button.team_profile":      {Other: "👥 Команда"},
+	"button.team_invite":       {Other: "📨 Пригласить участников"},
+	"button.team_disband":      {Other: "🗑 Распустить команду"},
+	"button.team_leave":        {Other: "🚪 Покинуть команду"},
+	"button.team_join":         {Other: "✅ Вступить в команду"},
+	"button.team_make_manager": {Other: "⭐ Сделать менеджером"},
+	"button.team_make_member":  {Other: "👤 Сделать участником"},
+	"button.team_remove":       {Other: "🚫 Исключить из команды"},
+	"profile.no_rating":        {Other: "пока нет отзывов"},
+	"profile.no_cities":        {Other: "не указаны"},
+	"profile.cities_prompt":    {Other: "🏙 В каких городах вы работаете? Перечислите через запятую, не больше 5.\n\nНапример: Алматы, Астана"},
+	"profile.cities_saved":     {Other: "✅ Города сохранены."},
+	"profile.too_many_cities":  {Other: "⚠️ Укажите не больше %d городов."},
+	"invite.hint":              {Other: "📨 Пригласите исполнителя в один из ваших открытых заказов:"},
+	"invite.notification":      {Other: "📨 %s приглашает вас в свой заказ."},
+	"invite.sent":              {Other: "✅ Приглашение отправлено: %s."},
+	"hire.done":                {Other: "🤝 Вы выбрали %s исполнителем заказа «%s». Закройте заказ после выполнения работы, чтобы оставить отзыв."},
+	"crew.screen":              {Other: "👥 Кто нужен для заказа? Добавьте исполнителей других специализаций, чтобы собрать команду.\n\nСейчас: %s"},
+	"crew.staffed":             {Other: "👥 Команда для заказа «%s» собрана. Отклики на заказ больше не принимаются."},
+	"hire.notification":        {Other: "🤝 Заказчик выбрал вас исполнителем заказа «%s». Поздравляем!"},
+	"group.admins_only":        {Other: "⚠️ Это могут сделать только администраторы группы."},
+	"group.linked":             {Other: "✅ Группа привязана к аккаунту %s. Новые заказы будут приходить сюда, и аккаунт или его команда смогут откликаться прямо отсюда. Отвязать: /unlink."},
+	"group.unlinked":           {Other: "✅ Группа отвязана. Заказы сюда больше не придут."},
+	"group.not_linked":         {Other: "ℹ️ Группа не привязана к аккаунту. Привязать её может администратор группы с аккаунтом исполнителя командой /link."},
+	"group.members_only":       {Other: "⛔ %s, откликаться здесь могут только привязанный аккаунт и его команда."},
+	"group.responded":          {Other: "✅ %s откликнулся от имени %s на заказ \"%s\". Заказчик получил профиль."},
+	"team_role.owner":          {Other: "владелец"},
+	"team_role.manager":        {Other: "менеджер"},
+	"team_role.member":         {Other: "участник"},
+	"team.name_prompt":         {Other: "👥 Как называется ваша команда? Это имя увидят заказчики, например название вашей студии."},
+	"team.member_row":          {Other: "• <b>%s</b> · %s · %s"},
+	"team.invite_link":         {Other: "📨 Ссылка-приглашение для новых участников:\n%s"},
+	"team.invite_text":         {Other: "Присоединяйтесь к %s в LensHub"},
+	"team.already_member":      {Other: "Вы уже состоите в команде. Сначала покиньте её, чтобы создать другую или вступить в другую."},
+	"team.created":             {Other: "✅ Команда «%s» создана. Отправьте ссылку-приглашение коллегам."},
+	"team.join_prompt":         {Other: "📨 Вас приглашают в команду «%s»."},
+	"team.joined":              {Other: "✅ Вы вступили в команду «%s»."},
+	"team.member_joined":       {Other: "👥 %s вступил(а) в команду «%s»."},
+	"team.member":              {Other: "👤 <b>%s</b>\n🎯 %s\n👥 Роль: %s"},
+	"team.role_changed":        {Other: "👥 Ваша роль в команде «%s» теперь: %s."},
+	"team.removed":             {Other: "👥 Вы больше не состоите в команде «%s»."},
+	"team.member_removed":      {Other: "✅ %s исключён(а) из команды."},
+	"team.left":                {Other: "✅ Вы покинули команду «%s»."},
+	"team.member_left":         {Other: "👥 %s покинул(а) команду «%s»."},
+	"team.disbanded":           {Other: "👥 Команда «%s» распущена."},
+	"team.has_open_orders":     {Other: "🔒 У команды есть открытые заказы, на которые её наняли. Завершите их вместе с заказчиками, прежде чем распускать команду."},
+	"team.assign_prompt":       {Other: "👥 Вашу команду выбрали для заказа «%s». Кто будет его выполнять?"},
+	"team.assign_done":         {Other: "✅ Заказ «%s» поручен: %s."},
+	"team.assigned":            {Other: "👥 %s поручила вам заказ «%s».\n📍 %s"},
+	"team.responded_as": {
+		One:   "👥 От имени команды <b>%s</b> (%d участник)",
+		Few:   "👥 От имени команды <b>%s</b> (%d участника)",
+		Many:  "👥 От имени команды <b>%s</b> (%d участников)",
+		Other: "👥 От имени команды <b>%s</b> (%d участника)",
+	},
+	"review.prompt":        {Other: "⭐ Как исполнитель справился с заказом «%s»? Оцените работу от 1 до 5."},
+	"review.prompt_member": {Other: "⭐ Оцените работу исполнителя %s по заказу «%s» от 1 до 5."},
+	"review.duplicate":     {Other: "Вы уже оценили этот заказ."},
+	"review.thanks":        {Other: "🙏 Спасибо за отзыв!"},
+	"profile.rating": {
+		One:   "%.1f ⭐ (%d отзыв)",
+		Few:   "%.1f ⭐ (%d отзыва)",
+		Many:  "%.1f ⭐ (%d отзывов)",
+		Other: "%.1f ⭐ (%d отзыва)",
+	},
+	"button.invite": {Other: "📨 Пригласить друзей"},
+	"invite.text":   {Other: "Найдите фотографов, видеографов и дизайнеров для своих проектов"},
+
+	"order.specialization_prompt": {Other: `🎯 Выберите тип специалиста для вашего заказа:
+
+- Видеооператор - съемка и монтаж видео
+- Фотограф - фотосъемка и обработка фото`},
+	"order.title_prompt": {Other: `📝 Отлично! Теперь введите название заказа:
+Например: "Свадебная фотосессия" или "Видеосъёмка дня рождения"`},
+	"order.description_prompt": {Other: `📝 Отлично! Теперь опишите подробности заказа:
+
+Например:
+- Что конкретно нужно сделать
+- Когда планируется съемка
+- Особые пожелания или требования`},
+	"order.location_prompt": {Other: `📍 Укажите место проведения съемки:
+
+Например: "Алматы, парк Горького" или "Студия на Абая 150"`},
+	"order.create_error": {Other: "❌ Произошла ошибка при создании заказа. Попробуйте еще раз."},
+	"order.executors_notified": {
+		One:  "📣 Уведомление о заказе получил %d исполнитель.",
+		Few:  "📣 Уведомление о заказе получили %d исполнителя.",
+		Many: "📣 Уведомление о заказе получили %d исполнителей.",
+	},
+	"order.no_executors": {Other: "📣 Пока нет исполнителей с нужной специализацией. Мы сообщим им о заказе, как только они появятся."},
+	"order.card": {Other: `%s
+
+📋 <b>%s</b>
+🎯 %s
+📝 %s
+📍 %s`},
+	"order_status.draft":           {Other: "📝 Черновик — проверьте заказ и опубликуйте его"},
+	"order_status.pending_review":  {Other: "⏳ На модерации"},
+	"order_status.open":            {Other: "✅ Опубликован"},
+	"order_status.closed":          {Other: "🔒 Закрыт"},
+	"order_status.rejected":        {Other: "❌ Отклонён модератором"},
+	"order_status.cancelled":       {Other: "🚫 Отменён"},
+	"order_status.staffed":         {Other: "👥 Команда собрана"},
+	"button.edit_title":            {Other: "✏️ Изменить название"},
+	"button.edit_description":      {Other: "✏️ Изменить описание"},
+	"button.edit_location":         {Other: "✏️ Изменить место"},
+	"button.publish":               {Other: "🚀 Опубликовать"},
+	"button.close_order":           {Other: "🔒 Закрыть заказ"},
+	"button.cancel_order":          {Other: "🚫 Отменить заказ"},
+	"button.crew":                  {Other: "👥 Состав команды"},
+	"button.repost":                {Other: "🔁 Опубликовать заново"},
+	"order.list":                   {Other: "📋 Ваши заказы:"},
+	"order.list_empty":             {Other: "У вас пока нет заказов."},
+	"order.update_error":           {Other: "❌ Не удалось сохранить изменения. Возможно, заказ уже закрыт."},
+	"order.updated":                {Other: "✅ Изменения сохранены."},
+	"order.updated_pending_review": {Other: "⏳ Изменения сохранены. Исполнители и каналы увидят новую версию после проверки модератором."},
+	"order.closed":                 {Other: "🔒 Заказ закрыт. Его можно опубликовать заново в любой момент."},
+	"order.cancelled":              {Other: "🚫 Заказ отменён и удалён из каналов. Вы можете разместить его снова в любой момент."},
+	"channel.order_closed":         {Other: "🔒 <b>Заказ закрыт.</b>"},
+	"button.respond":               {Other: "✅ Откликнуться"},
+	"button.report":                {Other: "⚠️ Пожаловаться"},
+
+	"response.save_error":    {Other: "❌ Произошла ошибка при сохранении отклика. Пожалуйста, попробуйте позже."},
+	"response.note_prompt":   {Other: "💬 Добавьте короткое сообщение для заказчика: стоимость, опыт или когда вы свободны. Или пропустите этот шаг."},
+	"response.note_too_long": {Other: "❌ Сообщение слишком длинное, уложитесь в %d символов."},
+	"response.confirmed": {Other: `✅ Вы успешно откликнулись на заказ!
+
+Заказчик получит уведомление с вашим профилем и свяжется с вами через Telegram.`},
+	"button.executor_portfolio": {Other: "🎨 Портфолио исполнителя"},
+
+	"report.error":     {Other: "❌ Не удалось отправить жалобу. Пожалуйста, попробуйте позже."},
+	"report.duplicate": {Other: "Вы уже отправили жалобу. Модераторы её рассмотрят."},
+	"report.self":      {Other: "Нельзя пожаловаться на самого себя."},
+	"report.sent":      {Other: "✅ Спасибо! Жалоба отправлена модераторам."},
+
+	"admin.yes":         {Other: "да"},
+	"admin.no":          {Other: "нет"},
+	"admin.audit_error": {Other: "❌ Не удалось записать действие в журнал аудита. Команда не выполнена."},
+	"admin.stats_error": {Other: "❌ Не удалось получить статистику."},
+	"admin.stats": {Other: `📊 Статистика
+
+👥 Заказчики: %d
+📸 Исполнители: %d
+🚫 Заблокированы: %d
+
+📋 Открытые заказы: %d
+📦 Всего заказов: %d
+🆕 Заказов сегодня: %d
+✅ Откликов: %d`},
+	"admin.user_usage":     {Other: "Использование: /user <chat_id>"},
+	"admin.user_error":     {Other: "❌ Не удалось получить пользователя."},
+	"admin.user_not_found": {Other: "Пользователь не найден."},
+	"admin.not_found":      {Other: "🔍 Ничего не найдено. Проверьте ID."},
+	"admin.invalid_state":  {Other: "⚠️ Сейчас это невозможно: например, заказ уже закрыт или жалоба уже обработана."},
+	"admin.user": {Other: `👤 Пользователь #%d
+
+Chat ID: %s
+Имя: %s
+Username: @%s
+Роль: %s
+Специализация: %s
+Портфолио: %s
+Заблокирован: %s`},
+	"admin.ban_usage":   {Other: "Использование: /ban <chat_id> или /unban <chat_id>"},
+	"admin.ban_error":   {Other: "❌ Не удалось изменить статус пользователя."},
+	"admin.banned":      {Other: "🚫 Пользователь %s заблокирован."},
+	"admin.unbanned":    {Other: "✅ Пользователь %s разблокирован."},
+	"admin.order_usage": {Other: "Использование: /order <id>"},
+	"admin.order_error": {Other: "❌ Не удалось получить заказ."},
+	"admin.order": {Other: `📋 Заказ #%d
+
+Статус: %s
+Название: %s
+Специализация: %s
+Описание: %s
+Место: %s
+Создан: %s
+
+Заказчик: %s (@%s), chat ID %s`},
+	"admin.close_order_usage": {Other: "Использование: /close_order <id>"},
+	"admin.close_order_error": {Other: "❌ Не удалось закрыть заказ."},
+	"admin.order_closed":      {Other: "✅ Заказ #%s закрыт."},
+	"admin.broadcast_usage":   {Other: "Использование: /broadcast <текст>"},
+	"admin.broadcast_error":   {Other: "❌ Не удалось получить список получателей."},
+	"admin.broadcast_started": {
+		One:  "📣 Рассылка запущена: %d получатель.",
+		Few:  "📣 Рассылка запущена: %d получателя.",
+		Many: "📣 Рассылка запущена: %d получателей.",
+	},
+	"admin.broadcast_finished": {Other: "📣 Рассылка завершена: доставлено %d из %d."},
+
+	"admin.reports_error": {Other: "❌ Не удалось получить жалобы."},
+	"admin.reports_empty": {Other: "✅ Новых жалоб нет."},
+	"admin.report_card": {Other: `⚠️ Жалоба #%d от %s
+
+Нарушитель: %s (@%s), %s
+Chat ID: %s
+Заказ: %s
+
+Отправитель: %s (@%s), chat ID %s`},
+	"button.ban":                 {Other: "🚫 Заблокировать"},
+	"button.dismiss":             {Other: "✖️ Отклонить"},
+	"admin.report_error":         {Other: "❌ Не удалось получить жалобу."},
+	"admin.report_resolve_error": {Other: "❌ Не удалось обработать жалобу."},
+	"admin.report_banned":        {Other: "🚫 Жалоба #%d: пользователь %s заблокирован."},
+	"admin.report_dismissed":     {Other: "✖️ Жалоба #%d отклонена."},
+
+	"admin.moderation_error":  {Other: "❌ Не удалось получить заказы на модерации."},
+	"admin.moderation_empty":  {Other: "✅ Заказов на модерации нет."},
+	"admin.moderation_edited": {Other: "✏️ Изменён заказчиком. Проверьте эту версию заказа #%d, в прежних карточках устаревший текст."},
+	"admin.moderation_card": {Other: `🛡 Заказ #%d ожидает модерации
+
+📋 %s
+🎯 Специализация: %s
+📝 %s
+📍 %s
+🕒 %s
+
+Заказчик: %s (@%s), chat ID %s`},
+	"button.approve":          {Other: "✅ Одобрить"},
+	"button.reject":           {Other: "❌ Отклонить"},
+	"admin.moderation_failed": {Other: "❌ Не удалось обработать заказ #%s."},
+	"admin.order_approved":    {Other: "✅ Заказ #%s одобрен и отправлен исполнителям."},
+	"admin.order_rejected":    {Other: "❌ Заказ #%s отклонён."},
+	"admin.links_error":       {Other: "❌ Не удалось получить статистику ссылок."},
+	"admin.links_empty":       {Other: "Через ссылки бота ещё никто не открывал."},
+	"admin.links":             {Other: "🔗 Стартовые ссылки"},
+	"admin.links_row":         {Other: "<code>%s</code>: переходов %d, чатов %d, регистраций %d"},
+}
@@ -0,0 +1,155 @@
+// Package i18n holds the bot's message catalogs and picks the right
+// translation and plural form for a user's language. Catalog texts are
+// Telegram HTML; the values formatted into them are escaped.
+package i18n
+
+import (
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/markup"
+)
+
+type Lang string
+
+const (
+	RU Lang = "ru"
+	KK Lang = "kk"
+	EN Lang = "en"
+
+	Default = RU
+)
+
+// Supported lists the languages offered by the /language command, in the
+// order they are shown.
+var Supported = []Lang{RU, KK, EN}
+
+// Message is a single catalog entry. Messages that do not depend on a count
+// only set Other; plural messages set the forms their language needs.
+type Message struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+type Catalog map[string]Message
+
+var catalogs = map[Lang]Catalog{
+	RU: ru,
+	KK: kk,
+	EN: en,
+}
+
+// Parse recognizes a stored language value or a Telegram language_code
+// such as "en-US".
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+
+	lang := Lang(code)
+	if _, ok := catalogs[lang]; !ok {
+		return "", false
+	}
+	return lang, true
+}
+
+// Detect returns the language for a code, falling back to Default.
+func Detect(code string) Lang {
+	if lang, ok := Parse(code); ok {
+		return lang
+	}
+	return Default
+}
+
+// T formats the message stored under key. Missing keys fall back to the
+// default language and then to the key itself, so a gap in a catalog never
+// produces an empty message. Templated texts without fields, such as
+// prompts, can be rendered through T as well.
+func T(lang Lang, key string, args ...any) string {
+	if isTemplate(key) {
+		return R(lang, key, nil)
+	}
+
+	msg, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+	return format(msg.Other, args)
+}
+
+// N formats the plural message stored under key, choosing the form for n.
+// The args are passed to the chosen form, so n usually needs to be repeated
+// among them.
+func N(lang Lang, key string, n int, args ...any) string {
+	msg, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+
+	var form string
+	switch pluralForm(lang, n) {
+	case formOne:
+		form = msg.One
+	case formFew:
+		form = msg.Few
+	case formMany:
+		form = msg.Many
+	}
+	if form == "" {
+		form = msg.Other
+	}
+	return format(form, args)
+}
+
+func lookup(lang Lang, key string) (Message, bool) {
+	if msg, ok := catalogs[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := catalogs[Default][key]
+	return msg, ok
+}
+
+// format escapes the args for HTML; args that are themselves rendered
+// messages must be passed as markup.Safe.
+func format(text string, args []any) string {
+	if len(args) == 0 {
+		return text
+	}
+	return markup.Sprintf(markup.HTML, text, args...)
+}
+
+type form int
+
+const (
+	formOther form = iota
+	formOne
+	formFew
+	formMany
+)
+
+// pluralForm implements the CLDR cardinal rules for integers.
+func pluralForm(lang Lang, n int) form {
+	if n < 0 {
+		n = -n
+	}
+
+	switch lang {
+	case RU:
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return formOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return formFew
+		default:
+			return formMany
+		}
+	default:
+		if n == 1 {
+			return formOne
+		}
+		return formOther
+	}
+}
@@ -0,0 +1,159 @@
+package i18n
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"text/template"
+
+	"github.com/aidosgal/lenshub/internal/markup"
+)
+
+// The long texts live in text/template files, one per language, so their
+// wording can change without a release. The embedded files are the
+// defaults; a file with the same name in the override directory redefines
+// any of their blocks.
+//
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// Vars are the named values of a templated text. Values are escaped for
+// HTML unless they are markup.Safe.
+type Vars map[string]any
+
+// templateVars lists the templated texts and the fields each one gets.
+// Every language must define every text, using no other fields.
+var templateVars = map[string][]string{
+	"welcome":                            nil,
+	"registration.portfolio_prompt":      nil,
+	"registration.specialization_prompt": nil,
+	"registration.customer_done":         {"Name"},
+	"registration.executor_done":         {"Name", "Specialization"},
+	"profile.customer":                   {"Name", "UserName"},
+	"profile.executor":                   {"Name", "UserName", "Specialization"},
+	"executor.public_profile":            {"Name", "Specialization", "Rating", "CompletedJobs", "Cities"},
+	"team.profile":                       {"Name", "Rating", "CompletedJobs", "Members"},
+	"order.created":                      {"Title", "Description", "Location"},
+	"order.pending_review":               {"Title", "Description", "Location"},
+	"order.shared":                       {"Title", "Specialization", "Description", "Location"},
+	"order.channel_post":                 {"Title", "Specialization", "Description", "Location"},
+	"order.notification":                 {"Title", "Specialization", "Description", "Location", "CreatedAt"},
+	"order.changed_notification":         {"Title", "Description", "Location"},
+	"response.customer_notification":     {"Title", "Role", "Name", "UserName", "Specialization", "Note"},
+	"moderation.approved":                {"Title"},
+	"moderation.rejected":                {"Title"},
+}
+
+type templateSet map[Lang]*template.Template
+
+var templates atomic.Pointer[templateSet]
+
+func init() {
+	if err := LoadTemplates(""); err != nil {
+		panic(err)
+	}
+}
+
+// LoadTemplates switches to the embedded texts overridden by the files in
+// dir, which are named like the embedded ones, e.g. "ru.tmpl". An empty dir
+// means no overrides. The texts in use only change when all of the new ones
+// parse and render, so a broken edit never reaches users.
+func LoadTemplates(dir string) error {
+	set := make(templateSet, len(Supported))
+	for _, lang := range Supported {
+		tmpl, err := loadTemplate(lang, dir)
+		if err != nil {
+			return err
+		}
+		set[lang] = tmpl
+	}
+	templates.Store(&set)
+	return nil
+}
+
+func loadTemplate(lang Lang, dir string) (*template.Template, error) {
+	name := string(lang) + ".tmpl"
+
+	src, err := embedded.ReadFile("templates/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded %s: %w", name, err)
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing embedded %s: %w", name, err)
+	}
+
+	if dir != "" {
+		path := filepath.Join(dir, name)
+		src, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		default:
+			if tmpl, err = tmpl.Parse(string(src)); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", path, err)
+			}
+		}
+	}
+
+	for key, fields := range templateVars {
+		t := tmpl.Lookup(key)
+		if t == nil {
+			return nil, fmt.Errorf("%s: text %q is not defined", name, key)
+		}
+		sample := make(Vars, len(fields))
+		for _, field := range fields {
+			sample[field] = field
+		}
+		if err := t.Execute(io.Discard, sample); err != nil {
+			return nil, fmt.Errorf("%s: text %q: %w", name, key, err)
+		}
+	}
+	return tmpl, nil
+}
+
+func isTemplate(key string) bool {
+	_, ok := templateVars[key]
+	return ok
+}
+
+// R renders the templated text stored under key. Fields missing from vars
+// are left empty. Unknown keys render as the key itself, like in T.
+func R(lang Lang, key string, vars Vars) string {
+	fields, ok := templateVars[key]
+	if !ok {
+		return key
+	}
+
+	set := *templates.Load()
+	tmpl, ok := set[lang]
+	if !ok {
+		tmpl = set[Default]
+	}
+
+	data := make(Vars, len(fields))
+	for _, field := range fields {
+		switch v := vars[field].(type) {
+		case nil:
+			data[field] = ""
+		case markup.Safe:
+			data[field] = string(v)
+		default:
+			data[field] = markup.Escape(markup.HTML, fmt.Sprint(v))
+		}
+	}
+
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, key, data); err != nil {
+		// LoadTemplates rendered every text already, so this cannot
+		// happen with the fields above.
+		return key
+	}
+	return b.String()
+}
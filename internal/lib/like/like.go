@@ -0,0 +1,12 @@
+// Package like builds patterns for SQL LIKE and ILIKE that match user input
+// literally.
+package like
+
+import "strings"
+
+var escaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Contains returns a pattern matching values that contain text.
+func Contains(text string) string {
+	return "%" + escaper.Replace(text) + "%"
+}
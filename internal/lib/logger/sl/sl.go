@@ -0,0 +1,47 @@
+package sl
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// piiKeys are attribute keys whose values identify a person or carry free
+// text typed by a user. They are never written to the log as-is.
+var piiKeys = map[string]struct{}{
+	"name":       {},
+	"first_name": {},
+	"last_name":  {},
+	"user_name":  {},
+	"username":   {},
+	"phone":      {},
+	"portfolio":  {},
+	"text":       {},
+}
+
+func Err(err error) slog.Attr {
+	return slog.Attr{
+		Key:   "error",
+		Value: slog.StringValue(err.Error()),
+	}
+}
+
+// RedactPII is a slog.HandlerOptions.ReplaceAttr function that masks the
+// values of personal data attributes.
+func RedactPII(_ []string, a slog.Attr) slog.Attr {
+	if _, ok := piiKeys[strings.ToLower(a.Key)]; ok {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// ParseLevel converts a level name from the config into a slog.Level.
+// Unknown names fall back to def.
+func ParseLevel(name string, def slog.Level) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return def
+	}
+	return level
+}
@@ -0,0 +1,99 @@
+// Package markup builds message texts for Telegram's HTML and MarkdownV2
+// parse modes. Formats are trusted markup; every value put into them is
+// escaped unless it is marked Safe, so user input can never open an entity
+// or break the parse of the whole message.
+package markup
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Mode is a Telegram parse mode.
+type Mode string
+
+const (
+	HTML       Mode = "HTML"
+	MarkdownV2 Mode = "MarkdownV2"
+)
+
+// Safe is text that is already markup in the mode it is used with, such as
+// another rendered message. It is inserted as is.
+type Safe string
+
+// markdownV2Special are the characters MarkdownV2 requires to be escaped
+// outside of entities.
+const markdownV2Special = "_*[]()~`>#+-=|{}.!\\"
+
+var markdownV2Escaper = func() *strings.Replacer {
+	var pairs []string
+	for _, c := range markdownV2Special {
+		pairs = append(pairs, string(c), "\\"+string(c))
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// htmlEscaper escapes the only characters Telegram's HTML mode cares
+// about outside of tags.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Escape makes plain text safe to put into a message of the given mode.
+func Escape(mode Mode, text string) string {
+	switch mode {
+	case HTML:
+		return htmlEscaper.Replace(text)
+	case MarkdownV2:
+		return markdownV2Escaper.Replace(text)
+	}
+	return text
+}
+
+// Sprintf formats like fmt.Sprintf, escaping every argument that is not
+// Safe after it has been formatted with its verb.
+func Sprintf(mode Mode, format string, args ...any) string {
+	wrapped := make([]any, len(args))
+	for i, arg := range args {
+		if safe, ok := arg.(Safe); ok {
+			wrapped[i] = string(safe)
+			continue
+		}
+		wrapped[i] = escaped{mode: mode, value: arg}
+	}
+	return fmt.Sprintf(format, wrapped...)
+}
+
+// escaped formats its value with the verb and flags it was given and
+// escapes the result.
+type escaped struct {
+	mode  Mode
+	value any
+}
+
+func (e escaped) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, Escape(e.mode, fmt.Sprintf(fmt.FormatString(f, verb), e.value)))
+}
+
+var (
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+	// markdownV2Entity matches the unescaped entity characters of
+	// MarkdownV2; escaped characters are left to markdownV2Unescape.
+	markdownV2Entity   = regexp.MustCompile("(^|[^\\\\])[*_~|`]+")
+	markdownV2Link     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownV2Unescape = regexp.MustCompile(`\\(.)`)
+)
+
+// Plain strips the markup from a text, leaving what the user would read.
+// It is the fallback for messages Telegram failed to parse.
+func Plain(mode Mode, text string) string {
+	switch mode {
+	case HTML:
+		return html.UnescapeString(htmlTag.ReplaceAllString(text, ""))
+	case MarkdownV2:
+		text = markdownV2Link.ReplaceAllString(text, "$1")
+		text = markdownV2Entity.ReplaceAllString(text, "$1")
+		return markdownV2Unescape.ReplaceAllString(text, "$1")
+	}
+	return text
+}
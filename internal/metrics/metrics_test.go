@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestCounterExposition(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_empty_total", "Never incremented.")
+	c := r.NewCounterVec("test_updates_total", "Updates, by type.", "type")
+	c.Inc("message")
+	c.Add(2.5, "callback_query")
+	c.Inc("message")
+
+	want := `# HELP test_empty_total Never incremented.
+# TYPE test_empty_total counter
+test_empty_total 0
+# HELP test_updates_total Updates, by type.
+# TYPE test_updates_total counter
+test_updates_total{type="callback_query"} 2.5
+test_updates_total{type="message"} 2
+`
+	if got := scrape(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLabelEscaping(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "Help with a \\ and a\nsecond line.", "value")
+	c.Inc("back\\slash \"quoted\"\nnext")
+	// Only \, " and line feeds are escaped: tabs and non-ASCII text are
+	// written as they are.
+	c.Inc("Алматы\tтаб")
+
+	want := `# HELP test_total Help with a \\ and a\nsecond line.
+# TYPE test_total counter
+test_total{value="back\\slash \"quoted\"\nnext"} 1
+test_total{value="Алматы	таб"} 1
+`
+	if got := scrape(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHistogramExposition(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("test_seconds", "Durations, by handler.", []float64{0.1, 1}, "handler")
+	h.Observe(0.05, "flow_order")
+	h.Observe(0.5, "flow_order")
+	h.Observe(3, "flow_order")
+
+	want := `# HELP test_seconds Durations, by handler.
+# TYPE test_seconds histogram
+test_seconds_bucket{handler="flow_order",le="0.1"} 1
+test_seconds_bucket{handler="flow_order",le="1"} 2
+test_seconds_bucket{handler="flow_order",le="+Inf"} 3
+test_seconds_sum{handler="flow_order"} 3.55
+test_seconds_count{handler="flow_order"} 3
+`
+	if got := scrape(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnlabelledHistogram(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("test_size", "Sizes.", []float64{10})
+	h.Observe(4)
+
+	want := `# HELP test_size Sizes.
+# TYPE test_size histogram
+test_size_bucket{le="10"} 1
+test_size_bucket{le="+Inf"} 1
+test_size_sum 4
+test_size_count 1
+`
+	if got := scrape(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,8 @@
+package model
+
+// ChannelPost is the message an order was posted as in a channel.
+type ChannelPost struct {
+    OrderID   int
+    ChatID    int64
+    MessageID int
+}
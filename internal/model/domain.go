@@ -0,0 +1,64 @@
+package model
+
+import "fmt"
+
+// Role is what a user does on the marketplace. Values are stored in the
+// database and must not be translated.
+type Role string
+
+const (
+    RoleCustomer Role = "customer"
+    RoleExecutor Role = "executor"
+)
+
+func (r Role) Valid() bool {
+    switch r {
+    case RoleCustomer, RoleExecutor:
+        return true
+    }
+    return false
+}
+
+func ParseRole(s string) (Role, error) {
+    r := Role(s)
+    if !r.Valid() {
+        return "", fmt.Errorf("invalid role %q", s)
+    }
+    return r, nil
+}
+
+// Specialization is the kind of work an executor does and an order needs.
+// Values are stored in the database and must not be translated.
+type Specialization string
+
+const (
+    SpecializationVideographer    Specialization = "videographer"
+    SpecializationPhotographer    Specialization = "photographer"
+    SpecializationMotionDesigner  Specialization = "motion_designer"
+    SpecializationGraphicDesigner Specialization = "graphic_designer"
+)
+
+// Specializations lists every specialization in the order they are offered.
+var Specializations = []Specialization{
+    SpecializationVideographer,
+    SpecializationPhotographer,
+    SpecializationMotionDesigner,
+    SpecializationGraphicDesigner,
+}
+
+func (s Specialization) Valid() bool {
+    for _, known := range Specializations {
+        if s == known {
+            return true
+        }
+    }
+    return false
+}
+
+func ParseSpecialization(s string) (Specialization, error) {
+    spec := Specialization(s)
+    if !spec.Valid() {
+        return "", fmt.Errorf("invalid specialization %q", s)
+    }
+    return spec, nil
+}
@@ -0,0 +1,11 @@
+package model
+
+// GroupChat is a Telegram group linked to an executor account, e.g. the
+// chat of an agency's coordinators. Orders for the account are sent to the
+// group as well, and its members may respond on the account's behalf.
+type GroupChat struct {
+    ChatID int64
+    Title  string
+    // UserID is the account the group acts for.
+    UserID int
+}
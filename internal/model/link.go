@@ -0,0 +1,10 @@
+package model
+
+// LinkStats shows how well a start link brings people in: how often it was
+// opened, by how many chats, and how many of them registered through it.
+type LinkStats struct {
+    Payload  string
+    Visits   int
+    Visitors int
+    SignUps  int
+}
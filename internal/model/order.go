@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"log/slog"
+	"time"
+)
 
 type Order struct {
     ID int 
@@ -8,6 +11,92 @@ type Order struct {
     Description string
     Location string
     User User
-    Specialization string
+    Specialization Specialization
+    Status string
     CreatedAt time.Time
+    // PublishedAt is when the order first went live, zero until it does.
+    PublishedAt time.Time
+    // ExecutorID is the executor the customer hired, 0 until they do.
+    ExecutorID int
+    // TeamID is set when the executor was hired as part of a team, which
+    // may assign the order to another of its members.
+    TeamID int
+    // Slots list the crew an order needs, one per specialization. Orders
+    // for a single executor have none and need their Specialization.
+    Slots []OrderSlot
+}
+
+// OrderSlot is the part of a crew order taken by executors of one
+// specialization.
+type OrderSlot struct {
+    ID             int
+    Specialization Specialization
+    Headcount      int
+    // Hired are the IDs of the executors hired for the slot.
+    Hired []int
+}
+
+// MaxSlotHeadcount is how many executors of one specialization an order
+// may need.
+const MaxSlotHeadcount = 10
+
+// Filled tells whether the slot has all the executors it needs.
+func (s OrderSlot) Filled() bool {
+    return len(s.Hired) >= s.Headcount
+}
+
+// Slot returns the slot for a specialization.
+func (o Order) Slot(spec Specialization) (OrderSlot, bool) {
+    for _, slot := range o.Slots {
+        if slot.Specialization == spec {
+            return slot, true
+        }
+    }
+    return OrderSlot{}, false
+}
+
+// Headcount is how many executors of a specialization the order needs.
+func (o Order) Headcount(spec Specialization) int {
+    if len(o.Slots) == 0 {
+        if spec == o.Specialization {
+            return 1
+        }
+        return 0
+    }
+    slot, _ := o.Slot(spec)
+    return slot.Headcount
+}
+
+// Specializations lists what the order needs, the main specialization
+// first.
+func (o Order) Specializations() []Specialization {
+    if len(o.Slots) == 0 {
+        return []Specialization{o.Specialization}
+    }
+    specs := make([]Specialization, 0, len(o.Slots))
+    for _, slot := range o.Slots {
+        specs = append(specs, slot.Specialization)
+    }
+    return specs
+}
+
+const (
+    OrderStatusDraft         = "draft"
+    OrderStatusPendingReview = "pending_review"
+    OrderStatusOpen          = "open"
+    OrderStatusClosed        = "closed"
+    OrderStatusRejected      = "rejected"
+    OrderStatusCancelled     = "cancelled"
+    // OrderStatusStaffed is a crew order all of whose slots are filled.
+    OrderStatusStaffed = "staffed"
+)
+
+// LogValue omits the free-form text and the customer's personal data.
+func (o Order) LogValue() slog.Value {
+    return slog.GroupValue(
+        slog.Int("id", o.ID),
+        slog.String("specialization", string(o.Specialization)),
+        slog.String("status", o.Status),
+        slog.Int("user_id", o.User.Id),
+    )
 }
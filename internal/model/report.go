@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type Report struct {
+    ID int
+    Reporter User
+    Target User
+    OrderID int // 0 when the report is not about a specific order
+    Status string
+    CreatedAt time.Time
+}
+
+const (
+    ReportStatusPending   = "pending"
+    ReportStatusBanned    = "banned"
+    ReportStatusDismissed = "dismissed"
+)
@@ -0,0 +1,10 @@
+package model
+
+// ExecutorStats is the track record shown on an executor's public profile.
+type ExecutorStats struct {
+    // Rating is the average of Reviews ratings from 1 to 5, 0 without
+    // reviews.
+    Rating        float64
+    Reviews       int
+    CompletedJobs int
+}
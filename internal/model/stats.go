@@ -0,0 +1,12 @@
+package model
+
+// Stats is a snapshot of marketplace activity shown to admins.
+type Stats struct {
+    Customers   int
+    Executors   int
+    BannedUsers int
+    OpenOrders  int
+    TotalOrders int
+    Responses   int
+    OrdersToday int
+}
@@ -0,0 +1,63 @@
+package model
+
+import "fmt"
+
+// TeamRole is what a member may do in a team. Values are stored in the
+// database and must not be translated.
+type TeamRole string
+
+const (
+    // TeamRoleOwner created the team and manages its members.
+    TeamRoleOwner TeamRole = "owner"
+    // TeamRoleManager responds to orders as the team and assigns them.
+    TeamRoleManager TeamRole = "manager"
+    // TeamRoleMember takes the orders assigned to them.
+    TeamRoleMember TeamRole = "member"
+)
+
+func (r TeamRole) Valid() bool {
+    switch r {
+    case TeamRoleOwner, TeamRoleManager, TeamRoleMember:
+        return true
+    }
+    return false
+}
+
+func ParseTeamRole(s string) (TeamRole, error) {
+    r := TeamRole(s)
+    if !r.Valid() {
+        return "", fmt.Errorf("invalid team role %q", s)
+    }
+    return r, nil
+}
+
+// ManagesOrders tells whether the role responds to orders as the team and
+// assigns them to members.
+func (r TeamRole) ManagesOrders() bool {
+    return r == TeamRoleOwner || r == TeamRoleManager
+}
+
+// Team is an agency or studio: several executors working under one name.
+type Team struct {
+    ID      int
+    Name    string
+    OwnerID int
+    // InviteCode is the secret part of the link executors join with.
+    InviteCode string
+    Members    []TeamMember
+}
+
+type TeamMember struct {
+    User User
+    Role TeamRole
+}
+
+// Member returns the member with the given user ID.
+func (t Team) Member(userID int) (TeamMember, bool) {
+    for _, member := range t.Members {
+        if member.User.Id == userID {
+            return member, true
+        }
+    }
+    return TeamMember{}, false
+}
@@ -1,11 +1,48 @@
 package model
 
+import "log/slog"
+
 type User struct {
     Id int `json:"id"`
     Name string `json:"name"`
     UserName string `json:"user_name"`
     ChatId string `json:"chat_id"`
-    Role string `json:"role"`
+    // Role is the active role; Roles lists every role the user holds.
+    Role Role `json:"role"`
+    Roles []Role `json:"roles"`
+    // Portfolio and Specialization belong to the executor profile.
     Portfolio string  `json:"portfolio"`
-    Specialization string `json:"specialization"`
+    Specialization Specialization `json:"specialization"`
+    Cities []string `json:"cities"`
+    Banned bool `json:"banned"`
+    Language string `json:"language"`
+    // Source is the start link that brought the user in, and ReferredBy
+    // the user who shared it when it was a referral link. Both are only
+    // written on registration.
+    Source string `json:"source"`
+    ReferredBy int `json:"referred_by"`
+    // TeamID is the team the executor works in and TeamRole their role
+    // there; both are empty outside a team.
+    TeamID int `json:"team_id"`
+    TeamRole TeamRole `json:"team_role"`
+}
+
+// HasRole reports whether the user holds the given role, active or not.
+func (u User) HasRole(role Role) bool {
+    for _, r := range u.Roles {
+        if r == role {
+            return true
+        }
+    }
+    return false
+}
+
+// LogValue keeps personal data such as names and usernames out of the logs.
+func (u User) LogValue() slog.Value {
+    return slog.GroupValue(
+        slog.Int("id", u.Id),
+        slog.String("role", string(u.Role)),
+        slog.String("specialization", string(u.Specialization)),
+        slog.String("language", u.Language),
+    )
 }
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/lib/like"
 	"github.com/aidosgal/lenshub/internal/model"
+	"github.com/lib/pq"
 )
 
 type UserRepository struct {
@@ -15,55 +19,171 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 }
 
 func(r *UserRepository) CreateUser(user model.User) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback()
+
     query := `
-        INSERT INTO users (name, user_name, chat_id, role, portfolio_url, specialization)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        INSERT INTO users (name, user_name, chat_id, role, language, source, referred_by)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, 0))
+        RETURNING id
     `
 
-    _, err := r.db.Exec(query, user.Name, user.UserName, user.ChatId, user.Role, user.Portfolio, user.Specialization)
+    var userID int
+    err = tx.QueryRow(query, user.Name, user.UserName, user.ChatId, user.Role, user.Language, user.Source, user.ReferredBy).Scan(&userID)
+    if err != nil {
+        return fmt.Errorf("error creating user: %w", errs.DB(err))
+    }
+
+    if err := insertRole(tx, userID, user); err != nil {
+        return err
+    }
+    return tx.Commit()
+}
+
+// AddRole gives an existing user another role, together with that role's
+// profile data, and makes it the active one.
+func (r *UserRepository) AddRole(user model.User) error {
+    tx, err := r.db.Begin()
     if err != nil {
         return err
     }
+    defer tx.Rollback()
+
+    var userID int
+    err = tx.QueryRow(`SELECT id FROM users WHERE chat_id = $1`, user.ChatId).Scan(&userID)
+    if err != nil {
+        return fmt.Errorf("error getting user %s: %w", user.ChatId, errs.DB(err))
+    }
+
+    if err := insertRole(tx, userID, user); err != nil {
+        return err
+    }
+    if _, err := tx.Exec(`UPDATE users SET role = $2 WHERE id = $1`, userID, user.Role); err != nil {
+        return err
+    }
+    return tx.Commit()
+}
+
+func insertRole(tx *sql.Tx, userID int, user model.User) error {
+    query := `
+        INSERT INTO user_roles (user_id, role, portfolio_url, specialization)
+        VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+    `
+
+    if _, err := tx.Exec(query, userID, user.Role, user.Portfolio, user.Specialization); err != nil {
+        return fmt.Errorf("error adding role %q: %w", user.Role, errs.DB(err))
+    }
     return nil
 }
 
-func (r *UserRepository) GetUserByChatID(chatID string) (*model.User, error) {
+// SetActiveRole switches the user to one of the roles they already hold.
+func (r *UserRepository) SetActiveRole(chatID string, role model.Role) error {
     query := `
-        SELECT id, name, user_name, chat_id, role, portfolio_url, specialization 
-        FROM users 
-        WHERE chat_id = $1
+        UPDATE users u
+        SET role = $2
+        WHERE u.chat_id = $1
+          AND EXISTS (SELECT 1 FROM user_roles r WHERE r.user_id = u.id AND r.role = $2)
     `
+
+    res, err := r.db.Exec(query, chatID, role)
+    if err != nil {
+        return err
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if affected == 0 {
+        return fmt.Errorf("user %s not found or does not hold role %q: %w", chatID, role, errs.ErrNotFound)
+    }
+    return nil
+}
+
+// GetUserByChatID returns the user behind a chat. Chats that have not
+// registered yield errs.ErrNotFound.
+func (r *UserRepository) GetUserByChatID(chatID string) (*model.User, error) {
+    user, err := r.getUser(`u.chat_id = $1`, chatID)
+    if err != nil {
+        return nil, fmt.Errorf("error getting user %s: %w", chatID, err)
+    }
+    return user, nil
+}
+
+// GetUserByID returns a user by their ID, for links shared outside the
+// user's own chat.
+func (r *UserRepository) GetUserByID(id int) (*model.User, error) {
+    user, err := r.getUser(`u.id = $1`, id)
+    if err != nil {
+        return nil, fmt.Errorf("error getting user %d: %w", id, err)
+    }
+    return user, nil
+}
+
+func (r *UserRepository) getUser(where string, arg any) (*model.User, error) {
+    query := `
+        SELECT
+            u.id,
+            u.name,
+            u.user_name,
+            u.chat_id,
+            u.role,
+            ARRAY(SELECT r.role FROM user_roles r WHERE r.user_id = u.id ORDER BY r.created_at),
+            COALESCE(ex.portfolio_url, ''),
+            COALESCE(ex.specialization, ''),
+            COALESCE(ex.cities, '{}'),
+            u.banned,
+            u.language,
+            COALESCE(tm.team_id, 0),
+            COALESCE(tm.role, '')
+        FROM users u
+        LEFT JOIN user_roles ex ON ex.user_id = u.id AND ex.role = 'executor'
+        LEFT JOIN team_members tm ON tm.user_id = u.id
+        WHERE ` + where
     
     user := &model.User{}
-    err := r.db.QueryRow(query, chatID).Scan(
+    var roles []string
+    err := r.db.QueryRow(query, arg).Scan(
         &user.Id,
         &user.Name,
         &user.UserName,
         &user.ChatId,
         &user.Role,
+        pq.Array(&roles),
         &user.Portfolio,
         &user.Specialization,
+        pq.Array(&user.Cities),
+        &user.Banned,
+        &user.Language,
+        &user.TeamID,
+        &user.TeamRole,
     )
 
-    if err == sql.ErrNoRows {
-        return nil, nil // User not found
+    if err != nil {
+        return nil, errs.DB(err)
     }
 
-    if err != nil {
-        return nil, err // Database error
+    for _, role := range roles {
+        user.Roles = append(user.Roles, model.Role(role))
     }
 
     return user, nil
 }
 
-func (r *UserRepository) GetUsersBySpecialization(specialization string) ([]model.User, error) {
+// GetUsersBySpecialization returns executors with the given specialization,
+// whichever role they currently have active.
+func (r *UserRepository) GetUsersBySpecialization(specialization model.Specialization) ([]model.User, error) {
     query := `
-        SELECT name, user_name, chat_id, role, portfolio_url, specialization 
-        FROM users 
-        WHERE specialization = $1 AND role = 'Исполнитель'
+        SELECT u.id, u.name, u.user_name, u.chat_id, ex.role, COALESCE(ex.portfolio_url, ''), ex.specialization, u.language
+        FROM users u
+        JOIN user_roles ex ON ex.user_id = u.id AND ex.role = $2
+        WHERE ex.specialization = $1 AND u.banned = FALSE
     `
     
-    rows, err := r.db.Query(query, specialization)
+    rows, err := r.db.Query(query, specialization, model.RoleExecutor)
     if err != nil {
         return nil, err
     }
@@ -73,17 +193,158 @@ func (r *UserRepository) GetUsersBySpecialization(specialization string) ([]mode
     for rows.Next() {
         var user model.User
         if err := rows.Scan(
+            &user.Id,
             &user.Name,
             &user.UserName,
             &user.ChatId,
             &user.Role,
             &user.Portfolio,
             &user.Specialization,
+            &user.Language,
+        ); err != nil {
+            return nil, err
+        }
+        users = append(users, user)
+    }
+
+    return users, rows.Err()
+}
+
+// SearchExecutors returns executors whose name or cities contain the text,
+// or who have one of the given specializations. Executors with reviews
+// come first.
+func (r *UserRepository) SearchExecutors(text string, specializations []model.Specialization, limit int) ([]model.User, error) {
+    query := `
+        SELECT u.id, u.name, u.user_name, u.chat_id, COALESCE(ex.portfolio_url, ''), ex.specialization, ex.cities, u.language
+        FROM users u
+        JOIN user_roles ex ON ex.user_id = u.id AND ex.role = $1
+        WHERE u.banned = FALSE
+          AND (u.name ILIKE $2 OR array_to_string(ex.cities, ' ') ILIKE $2 OR ex.specialization = ANY($3))
+        ORDER BY (SELECT COUNT(*) FROM reviews rv WHERE rv.executor_id = u.id) DESC, u.id
+        LIMIT $4
+    `
+
+    specs := make([]string, len(specializations))
+    for i, spec := range specializations {
+        specs[i] = string(spec)
+    }
+
+    rows, err := r.db.Query(query, model.RoleExecutor, like.Contains(text), pq.Array(specs), limit)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    var users []model.User
+    for rows.Next() {
+        user := model.User{Role: model.RoleExecutor, Roles: []model.Role{model.RoleExecutor}}
+        if err := rows.Scan(
+            &user.Id,
+            &user.Name,
+            &user.UserName,
+            &user.ChatId,
+            &user.Portfolio,
+            &user.Specialization,
+            pq.Array(&user.Cities),
+            &user.Language,
         ); err != nil {
             return nil, err
         }
         users = append(users, user)
     }
 
-    return users, nil
+    return users, rows.Err()
+}
+
+// SetCities replaces the cities an executor works in.
+func (r *UserRepository) SetCities(chatID string, cities []string) error {
+    query := `
+        UPDATE user_roles r
+        SET cities = $2
+        FROM users u
+        WHERE u.id = r.user_id AND u.chat_id = $1 AND r.role = $3
+    `
+
+    res, err := r.db.Exec(query, chatID, pq.Array(cities), model.RoleExecutor)
+    if err != nil {
+        return err
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if affected == 0 {
+        return fmt.Errorf("executor %s: %w", chatID, errs.ErrNotFound)
+    }
+    return nil
+}
+
+func (r *UserRepository) SetBanned(chatID string, banned bool) error {
+    query := `
+        UPDATE users
+        SET banned = $2
+        WHERE chat_id = $1
+    `
+
+    res, err := r.db.Exec(query, chatID, banned)
+    if err != nil {
+        return err
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if affected == 0 {
+        return fmt.Errorf("user %s: %w", chatID, errs.ErrNotFound)
+    }
+    return nil
+}
+
+func (r *UserRepository) GetActiveChatIDs() ([]string, error) {
+    query := `
+        SELECT chat_id
+        FROM users
+        WHERE banned = FALSE
+    `
+
+    rows, err := r.db.Query(query)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    var chatIDs []string
+    for rows.Next() {
+        var chatID string
+        if err := rows.Scan(&chatID); err != nil {
+            return nil, err
+        }
+        chatIDs = append(chatIDs, chatID)
+    }
+
+    return chatIDs, rows.Err()
+}
+
+func (r *UserRepository) SetLanguage(chatID string, language string) error {
+    query := `
+        UPDATE users
+        SET language = $2
+        WHERE chat_id = $1
+    `
+
+    res, err := r.db.Exec(query, chatID, language)
+    if err != nil {
+        return err
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if affected == 0 {
+        return fmt.Errorf("user %s: %w", chatID, errs.ErrNotFound)
+    }
+    return nil
 }
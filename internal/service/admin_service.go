@@ -0,0 +1,63 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+type AdminService struct {
+    db *sql.DB
+}
+
+func NewAdminService(db *sql.DB) *AdminService {
+    return &AdminService{db: db}
+}
+
+func (s *AdminService) GetStats() (model.Stats, error) {
+    query := `
+        SELECT
+            (SELECT COUNT(*) FROM user_roles WHERE role = $2),
+            (SELECT COUNT(*) FROM user_roles WHERE role = $3),
+            (SELECT COUNT(*) FROM users WHERE banned),
+            (SELECT COUNT(*) FROM orders WHERE status = $1),
+            (SELECT COUNT(*) FROM orders WHERE status <> $4),
+            (SELECT COUNT(*) FROM responses),
+            (SELECT COUNT(*) FROM orders WHERE status <> $4 AND created_at >= CURRENT_DATE)`
+
+    var stats model.Stats
+    err := s.db.QueryRow(query, model.OrderStatusOpen, model.RoleCustomer, model.RoleExecutor, model.OrderStatusDraft).Scan(
+        &stats.Customers,
+        &stats.Executors,
+        &stats.BannedUsers,
+        &stats.OpenOrders,
+        &stats.TotalOrders,
+        &stats.Responses,
+        &stats.OrdersToday,
+    )
+    if err != nil {
+        return model.Stats{}, fmt.Errorf("error getting stats: %w", err)
+    }
+
+    return stats, nil
+}
+
+// LogAction records an admin action in the audit log.
+func (s *AdminService) LogAction(adminChatID, action, target, details string) error {
+    query := `
+        INSERT INTO admin_audit_log(
+            admin_chat_id,
+            action,
+            target,
+            details,
+            created_at
+        ) VALUES ($1, $2, $3, $4, NOW())`
+
+    _, err := s.db.Exec(query, adminChatID, action, target, details)
+    if err != nil {
+        return fmt.Errorf("error writing audit log: %w", err)
+    }
+
+    return nil
+}
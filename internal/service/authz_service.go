@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+// Reasons an action is refused. The bot maps each of them to a message
+// telling the user what is wrong; other callers can match the errs
+// sentinel they wrap.
+var (
+    ErrNotRegistered          = fmt.Errorf("user is not registered: %w", errs.ErrForbidden)
+    ErrBanned                 = fmt.Errorf("user is banned: %w", errs.ErrForbidden)
+    ErrNotOrderOwner          = fmt.Errorf("order belongs to another user: %w", errs.ErrForbidden)
+    ErrOrderNotOpen           = fmt.Errorf("order is not open: %w", errs.ErrInvalidState)
+    ErrOwnOrder               = fmt.Errorf("cannot respond to own order: %w", errs.ErrForbidden)
+    ErrSpecializationMismatch = fmt.Errorf("order is for another specialization: %w", errs.ErrForbidden)
+    ErrNotInTeam              = fmt.Errorf("user is not in a team: %w", errs.ErrForbidden)
+    ErrTeamRoleRequired       = fmt.Errorf("team role does not allow it: %w", errs.ErrForbidden)
+    ErrSlotFilled             = fmt.Errorf("order needs no more executors of the specialization: %w", errs.ErrInvalidState)
+    ErrSelfReport             = fmt.Errorf("cannot report oneself: %w", errs.ErrForbidden)
+)
+
+// RoleRequiredError refuses an action that needs a role the user does not
+// hold.
+type RoleRequiredError struct {
+    Role model.Role
+}
+
+func (e *RoleRequiredError) Error() string {
+    return fmt.Sprintf("role %s required", e.Role)
+}
+
+func (e *RoleRequiredError) Unwrap() error {
+    return errs.ErrForbidden
+}
+
+type userGetter interface {
+    GetUserByChatID(chatID string) (*model.User, error)
+}
+
+type orderGetter interface {
+    GetOrderByID(orderID string) (model.Order, error)
+}
+
+// AuthzService decides who may do what. Every check loads the acting user
+// afresh, so bans and role changes apply to buttons sent before them.
+type AuthzService struct {
+    users  userGetter
+    orders orderGetter
+}
+
+func NewAuthzService(users userGetter, orders orderGetter) *AuthzService {
+    return &AuthzService{users: users, orders: orders}
+}
+
+// Actor returns the registered, unbanned user behind a chat.
+func (s *AuthzService) Actor(chatID string) (*model.User, error) {
+    user, err := s.users.GetUserByChatID(chatID)
+    if errors.Is(err, errs.ErrNotFound) {
+        return nil, ErrNotRegistered
+    }
+    if err != nil {
+        return nil, err
+    }
+    if user.Banned {
+        return nil, ErrBanned
+    }
+    return user, nil
+}
+
+// RequireRole returns the acting user if they hold the role. It does not
+// have to be their active one.
+func (s *AuthzService) RequireRole(chatID string, role model.Role) (*model.User, error) {
+    user, err := s.Actor(chatID)
+    if err != nil {
+        return nil, err
+    }
+    if !user.HasRole(role) {
+        return nil, &RoleRequiredError{Role: role}
+    }
+    return user, nil
+}
+
+// CanManageOrder lets customers view, edit, publish, close and repost
+// their own orders.
+func (s *AuthzService) CanManageOrder(chatID, orderID string) (*model.User, model.Order, error) {
+    user, err := s.RequireRole(chatID, model.RoleCustomer)
+    if err != nil {
+        return nil, model.Order{}, err
+    }
+
+    order, err := s.orders.GetOrderByID(orderID)
+    if err != nil {
+        return nil, model.Order{}, err
+    }
+    if order.User.Id != user.Id {
+        return nil, model.Order{}, ErrNotOrderOwner
+    }
+    return user, order, nil
+}
+
+// CanRespond lets executors respond to open orders of their
+// specialization posted by someone else.
+func (s *AuthzService) CanRespond(chatID, orderID string) (*model.User, model.Order, error) {
+    user, err := s.RequireRole(chatID, model.RoleExecutor)
+    if err != nil {
+        return nil, model.Order{}, err
+    }
+
+    order, err := s.orders.GetOrderByID(orderID)
+    if err != nil {
+        return nil, model.Order{}, err
+    }
+    switch {
+    case order.Status != model.OrderStatusOpen:
+        return nil, model.Order{}, ErrOrderNotOpen
+    case order.User.Id == user.Id:
+        return nil, model.Order{}, ErrOwnOrder
+    case order.Headcount(user.Specialization) == 0:
+        return nil, model.Order{}, ErrSpecializationMismatch
+    }
+    if slot, ok := order.Slot(user.Specialization); ok && slot.Filled() {
+        return nil, model.Order{}, ErrSlotFilled
+    }
+    return user, order, nil
+}
+
+// TeamMember returns the acting executor if they work in a team with one of
+// the given roles, or with any role when none are given.
+func (s *AuthzService) TeamMember(chatID string, roles ...model.TeamRole) (*model.User, error) {
+    user, err := s.RequireRole(chatID, model.RoleExecutor)
+    if err != nil {
+        return nil, err
+    }
+    if user.TeamID == 0 {
+        return nil, ErrNotInTeam
+    }
+    if len(roles) == 0 {
+        return user, nil
+    }
+    for _, role := range roles {
+        if user.TeamRole == role {
+            return user, nil
+        }
+    }
+    return nil, ErrTeamRoleRequired
+}
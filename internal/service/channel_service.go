@@ -0,0 +1,71 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+// ChannelService remembers where orders were posted in channels, so that
+// the posts can follow the orders.
+type ChannelService struct {
+    db *sql.DB
+}
+
+func NewChannelService(db *sql.DB) *ChannelService {
+    return &ChannelService{db: db}
+}
+
+// SavePost stores the message an order was posted as. Posting an order to
+// the same channel again replaces the previous post.
+func (s *ChannelService) SavePost(post model.ChannelPost) error {
+    query := `
+        INSERT INTO channel_posts(
+            order_id,
+            chat_id,
+            message_id,
+            created_at
+        ) VALUES ($1, $2, $3, NOW())
+        ON CONFLICT (order_id, chat_id) DO UPDATE SET message_id = EXCLUDED.message_id`
+
+    if _, err := s.db.Exec(query, post.OrderID, post.ChatID, post.MessageID); err != nil {
+        return fmt.Errorf("error saving channel post: %w", err)
+    }
+    return nil
+}
+
+// GetPosts returns the posts of an order in every channel.
+func (s *ChannelService) GetPosts(orderID int) ([]model.ChannelPost, error) {
+    query := `
+        SELECT order_id, chat_id, message_id
+        FROM channel_posts
+        WHERE order_id = $1`
+
+    rows, err := s.db.Query(query, orderID)
+    if err != nil {
+        return nil, fmt.Errorf("error getting channel posts: %w", err)
+    }
+    defer rows.Close()
+
+    var posts []model.ChannelPost
+    for rows.Next() {
+        var post model.ChannelPost
+        if err := rows.Scan(&post.OrderID, &post.ChatID, &post.MessageID); err != nil {
+            return nil, fmt.Errorf("error getting channel posts: %w", err)
+        }
+        posts = append(posts, post)
+    }
+
+    return posts, rows.Err()
+}
+
+// DeletePost forgets a post that was removed from its channel.
+func (s *ChannelService) DeletePost(post model.ChannelPost) error {
+    query := `DELETE FROM channel_posts WHERE order_id = $1 AND chat_id = $2`
+
+    if _, err := s.db.Exec(query, post.OrderID, post.ChatID); err != nil {
+        return fmt.Errorf("error deleting channel post: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+// GroupService keeps track of the group chats linked to executor accounts.
+type GroupService struct {
+    db *sql.DB
+}
+
+func NewGroupService(db *sql.DB) *GroupService {
+    return &GroupService{db: db}
+}
+
+// LinkGroup links a group to an account, replacing the account it was
+// linked to before. linkedBy is the chat ID of the member who linked it.
+func (s *GroupService) LinkGroup(group model.GroupChat, linkedBy int64) error {
+    query := `
+        INSERT INTO group_chats(
+            chat_id,
+            title,
+            user_id,
+            linked_by,
+            created_at
+        ) VALUES ($1, $2, $3, $4, NOW())
+        ON CONFLICT (chat_id) DO UPDATE
+        SET title = EXCLUDED.title, user_id = EXCLUDED.user_id, linked_by = EXCLUDED.linked_by, created_at = NOW()`
+
+    if _, err := s.db.Exec(query, group.ChatID, group.Title, group.UserID, linkedBy); err != nil {
+        return fmt.Errorf("error linking group: %w", errs.DB(err))
+    }
+    return nil
+}
+
+// UnlinkGroup forgets a group. It reports whether the group was linked.
+func (s *GroupService) UnlinkGroup(chatID int64) (bool, error) {
+    res, err := s.db.Exec(`DELETE FROM group_chats WHERE chat_id = $1`, chatID)
+    if err != nil {
+        return false, fmt.Errorf("error unlinking group: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return false, fmt.Errorf("error unlinking group: %w", err)
+    }
+    return affected > 0, nil
+}
+
+// MoveGroup follows a group that Telegram turned into a supergroup, which
+// gives it a new chat ID.
+func (s *GroupService) MoveGroup(oldChatID, newChatID int64) error {
+    query := `UPDATE group_chats SET chat_id = $2 WHERE chat_id = $1`
+
+    if _, err := s.db.Exec(query, oldChatID, newChatID); err != nil {
+        return fmt.Errorf("error moving group: %w", errs.DB(err))
+    }
+    return nil
+}
+
+// GetGroup returns the link of a group, or errs.ErrNotFound when the group
+// is not linked.
+func (s *GroupService) GetGroup(chatID int64) (model.GroupChat, error) {
+    query := `
+        SELECT chat_id, title, user_id
+        FROM group_chats
+        WHERE chat_id = $1`
+
+    var group model.GroupChat
+    err := s.db.QueryRow(query, chatID).Scan(&group.ChatID, &group.Title, &group.UserID)
+    if err != nil {
+        return model.GroupChat{}, fmt.Errorf("error getting group %d: %w", chatID, errs.DB(err))
+    }
+    return group, nil
+}
+
+// GetGroupsByUser returns the groups linked to an account.
+func (s *GroupService) GetGroupsByUser(userID int) ([]model.GroupChat, error) {
+    query := `
+        SELECT chat_id, title, user_id
+        FROM group_chats
+        WHERE user_id = $1
+        ORDER BY created_at`
+
+    rows, err := s.db.Query(query, userID)
+    if err != nil {
+        return nil, fmt.Errorf("error getting groups: %w", err)
+    }
+    defer rows.Close()
+
+    var groups []model.GroupChat
+    for rows.Next() {
+        var group model.GroupChat
+        if err := rows.Scan(&group.ChatID, &group.Title, &group.UserID); err != nil {
+            return nil, fmt.Errorf("error getting groups: %w", err)
+        }
+        groups = append(groups, group)
+    }
+
+    return groups, rows.Err()
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+// LinkService tracks the start links people open the bot with.
+type LinkService struct {
+    db *sql.DB
+}
+
+func NewLinkService(db *sql.DB) *LinkService {
+    return &LinkService{db: db}
+}
+
+// RecordVisit stores that a chat opened the bot with a start link.
+// registered tells whether the chat had an account already.
+func (s *LinkService) RecordVisit(chatID, payload, kind string, registered bool) error {
+    query := `
+        INSERT INTO start_link_visits(
+            chat_id,
+            payload,
+            kind,
+            registered,
+            created_at
+        ) VALUES ($1, $2, $3, $4, NOW())`
+
+    if _, err := s.db.Exec(query, chatID, payload, kind, registered); err != nil {
+        return fmt.Errorf("error recording start link visit: %w", err)
+    }
+    return nil
+}
+
+// GetLinkStats returns the links that brought in most users first.
+func (s *LinkService) GetLinkStats(limit int) ([]model.LinkStats, error) {
+    query := `
+        SELECT
+            v.payload,
+            COUNT(*),
+            COUNT(DISTINCT v.chat_id),
+            (SELECT COUNT(*) FROM users u WHERE u.source = v.payload) AS sign_ups
+        FROM start_link_visits v
+        GROUP BY v.payload
+        ORDER BY sign_ups DESC, COUNT(*) DESC
+        LIMIT $1`
+
+    rows, err := s.db.Query(query, limit)
+    if err != nil {
+        return nil, fmt.Errorf("error getting link stats: %w", err)
+    }
+    defer rows.Close()
+
+    var stats []model.LinkStats
+    for rows.Next() {
+        var link model.LinkStats
+        if err := rows.Scan(&link.Payload, &link.Visits, &link.Visitors, &link.SignUps); err != nil {
+            return nil, fmt.Errorf("error getting link stats: %w", err)
+        }
+        stats = append(stats, link)
+    }
+
+    return stats, rows.Err()
+}
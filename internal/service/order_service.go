@@ -2,21 +2,56 @@ package service
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/lib/like"
 	"github.com/aidosgal/lenshub/internal/model"
+	"github.com/lib/pq"
 )
 
 type OrderService struct {
     db *sql.DB
+    // moderateFirst is how many published orders a customer needs before
+    // their new orders skip moderation.
+    moderateFirst int
 }
 
-func NewOrderService(db *sql.DB) *OrderService {
-    return &OrderService{db: db}
+func NewOrderService(db *sql.DB, moderateFirst int) *OrderService {
+    return &OrderService{db: db, moderateFirst: moderateFirst}
+}
+
+// RequiresModeration applies the trust rule: the first orders of a customer
+// are held for review until enough of them have been approved.
+func (s *OrderService) RequiresModeration(userID int) (bool, error) {
+    if s.moderateFirst <= 0 {
+        return false, nil
+    }
+
+    query := `
+        SELECT COUNT(*)
+        FROM orders
+        WHERE user_id = $1 AND status IN ($2, $3, $4)`
+
+    var published int
+    err := s.db.QueryRow(query, userID, model.OrderStatusOpen, model.OrderStatusClosed, model.OrderStatusStaffed).Scan(&published)
+    if err != nil {
+        return false, fmt.Errorf("error checking customer trust: %w", err)
+    }
+
+    return published < s.moderateFirst, nil
 }
 
 func (s *OrderService) CreateOrder(order model.Order) (model.Order, error) {
+    if !order.Specialization.Valid() {
+        return model.Order{}, fmt.Errorf("invalid specialization %q", order.Specialization)
+    }
+    if order.Status == "" {
+        order.Status = model.OrderStatusOpen
+    }
+
     query := `
         WITH inserted_order AS (
             INSERT INTO orders (
@@ -25,9 +60,10 @@ func (s *OrderService) CreateOrder(order model.Order) (model.Order, error) {
                 location, 
                 user_id, 
                 specialization, 
+                status,
                 created_at
-            ) VALUES ($1, $2, $3, $4, $5, $6)
-            RETURNING id, title, description, location, specialization, created_at, user_id
+            ) VALUES ($1, $2, $3, $4, $5, $6, $7)
+            RETURNING id, title, description, location, specialization, status, created_at, user_id
         )
         SELECT 
             o.id,
@@ -35,16 +71,19 @@ func (s *OrderService) CreateOrder(order model.Order) (model.Order, error) {
             o.description,
             o.location,
             o.specialization,
+            o.status,
             o.created_at,
             u.id as user_id,
             u.name,
             u.user_name,
             u.chat_id,
             u.role,
-            u.portfolio_url,
-            u.specialization as user_specialization
+            COALESCE(ex.portfolio_url, ''),
+            COALESCE(ex.specialization, '') as user_specialization,
+            u.language
         FROM inserted_order o
-        JOIN users u ON o.user_id = u.id`
+        JOIN users u ON o.user_id = u.id
+        LEFT JOIN user_roles ex ON ex.user_id = u.id AND ex.role = 'executor'`
 
     var createdOrder model.Order
     var user model.User
@@ -56,6 +95,7 @@ func (s *OrderService) CreateOrder(order model.Order) (model.Order, error) {
         order.Location,
         order.User.Id,
         order.Specialization,
+        order.Status,
         order.CreatedAt,
     ).Scan(
         &createdOrder.ID,
@@ -63,6 +103,7 @@ func (s *OrderService) CreateOrder(order model.Order) (model.Order, error) {
         &createdOrder.Description,
         &createdOrder.Location,
         &createdOrder.Specialization,
+        &createdOrder.Status,
         &createdOrder.CreatedAt,
         &user.Id,
         &user.Name,
@@ -71,18 +112,29 @@ func (s *OrderService) CreateOrder(order model.Order) (model.Order, error) {
         &user.Role,
         &user.Portfolio,
         &user.Specialization,
+        &user.Language,
     )
 
     if err != nil {
-        return model.Order{}, fmt.Errorf("error creating order: %v", err)
+        return model.Order{}, fmt.Errorf("error creating order: %w", errs.DB(err))
     }
 
     createdOrder.User = user
     return createdOrder, nil
 }
 
+// parseOrderID reads an order ID from a callback or a command. An ID that
+// is not a number names no order.
+func parseOrderID(orderID string) (int, error) {
+    id, err := strconv.Atoi(orderID)
+    if err != nil {
+        return 0, fmt.Errorf("invalid order id %q: %w", orderID, errs.ErrNotFound)
+    }
+    return id, nil
+}
+
 func (s *OrderService) GetOrderByID(orderID string) (model.Order, error) {
-    order_id, err := strconv.Atoi(orderID)
+    order_id, err := parseOrderID(orderID)
     if err != nil {
         return model.Order{}, err
     }
@@ -94,20 +146,27 @@ func (s *OrderService) GetOrderByID(orderID string) (model.Order, error) {
             o.description,
             o.location,
             o.specialization,
+            o.status,
             o.created_at,
+            o.published_at,
             u.id as user_id,
             u.name,
             u.user_name,
             u.chat_id,
             u.role,
-            u.portfolio_url,
-            u.specialization as user_specialization
+            COALESCE(ex.portfolio_url, ''),
+            COALESCE(ex.specialization, '') as user_specialization,
+            u.language,
+            COALESCE(o.executor_id, 0),
+            COALESCE(o.team_id, 0)
         FROM orders o
         JOIN users u ON o.user_id = u.id
+        LEFT JOIN user_roles ex ON ex.user_id = u.id AND ex.role = 'executor'
         WHERE o.id = $1`
 
     var order model.Order
     var user model.User
+    var publishedAt sql.NullTime
 
     err = s.db.QueryRow(query, order_id).Scan(
         &order.ID,
@@ -115,7 +174,9 @@ func (s *OrderService) GetOrderByID(orderID string) (model.Order, error) {
         &order.Description,
         &order.Location,
         &order.Specialization,
+        &order.Status,
         &order.CreatedAt,
+        &publishedAt,
         &user.Id,
         &user.Name,
         &user.UserName,
@@ -123,15 +184,628 @@ func (s *OrderService) GetOrderByID(orderID string) (model.Order, error) {
         &user.Role,
         &user.Portfolio,
         &user.Specialization,
+        &user.Language,
+        &order.ExecutorID,
+        &order.TeamID,
     )
 
     if err != nil {
-        if err == sql.ErrNoRows {
-            return model.Order{}, fmt.Errorf("order not found")
-        }
-        return model.Order{}, fmt.Errorf("error getting order: %v", err)
+        return model.Order{}, fmt.Errorf("error getting order %d: %w", order_id, errs.DB(err))
+    }
+    order.PublishedAt = publishedAt.Time
+
+    orders := []model.Order{order}
+    if err := s.attachSlots(orders); err != nil {
+        return model.Order{}, err
     }
+    order = orders[0]
 
     order.User = user
     return order, nil
 }
+
+func (s *OrderService) CloseOrder(orderID string) error {
+    order_id, err := parseOrderID(orderID)
+    if err != nil {
+        return err
+    }
+
+    query := `
+        UPDATE orders
+        SET status = $2, closed_at = NOW()
+        WHERE id = $1 AND status NOT IN ($2, $3)`
+
+    res, err := s.db.Exec(query, order_id, model.OrderStatusClosed, model.OrderStatusCancelled)
+    if err != nil {
+        return fmt.Errorf("error closing order: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error closing order: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %d not found, closed or cancelled: %w", order_id, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+// CancelOrder withdraws an order that is published, staffed or waiting for
+// review, e.g. when the event was called off.
+func (s *OrderService) CancelOrder(orderID string) error {
+    order_id, err := parseOrderID(orderID)
+    if err != nil {
+        return err
+    }
+
+    query := `
+        UPDATE orders
+        SET status = $2, closed_at = NOW()
+        WHERE id = $1 AND status IN ($3, $4, $5)`
+
+    res, err := s.db.Exec(query, order_id, model.OrderStatusCancelled, model.OrderStatusOpen, model.OrderStatusPendingReview, model.OrderStatusStaffed)
+    if err != nil {
+        return fmt.Errorf("error cancelling order: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error cancelling order: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %d not found or not active: %w", order_id, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+// CancelUserOrders cancels the orders of a customer that are open or
+// waiting for review, e.g. when the customer is banned, and returns them.
+// Staffed orders are left to the executors already hired.
+func (s *OrderService) CancelUserOrders(userID int) ([]model.Order, error) {
+    query := `
+        UPDATE orders
+        SET status = $2, closed_at = NOW()
+        WHERE user_id = $1 AND status IN ($3, $4)
+        RETURNING id`
+
+    rows, err := s.db.Query(query, userID, model.OrderStatusCancelled, model.OrderStatusOpen, model.OrderStatusPendingReview)
+    if err != nil {
+        return nil, fmt.Errorf("error cancelling orders of user %d: %w", userID, err)
+    }
+    defer rows.Close()
+
+    var ids []int64
+    for rows.Next() {
+        var id int64
+        if err := rows.Scan(&id); err != nil {
+            return nil, fmt.Errorf("error cancelling orders of user %d: %w", userID, err)
+        }
+        ids = append(ids, id)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error cancelling orders of user %d: %w", userID, err)
+    }
+    if len(ids) == 0 {
+        return nil, nil
+    }
+    return s.getOrders(`WHERE o.id = ANY($1)`, pq.Int64Array(ids))
+}
+
+// ApproveOrder takes an order held for review live. edited tells whether
+// the order was live before and came back for review after an edit.
+func (s *OrderService) ApproveOrder(orderID string) (edited bool, err error) {
+    order_id, err := parseOrderID(orderID)
+    if err != nil {
+        return false, err
+    }
+
+    query := `
+        UPDATE orders o
+        SET status = $2, published_at = COALESCE(old.published_at, NOW())
+        FROM (SELECT id, published_at FROM orders WHERE id = $1 FOR UPDATE) old
+        WHERE o.id = old.id AND o.status = $3
+        RETURNING old.published_at IS NOT NULL`
+
+    err = s.db.QueryRow(query, order_id, model.OrderStatusOpen, model.OrderStatusPendingReview).Scan(&edited)
+    if errors.Is(err, sql.ErrNoRows) {
+        return false, fmt.Errorf("order %d not found or already moderated: %w", order_id, errs.ErrInvalidState)
+    }
+    if err != nil {
+        return false, fmt.Errorf("error moderating order: %w", err)
+    }
+    return edited, nil
+}
+
+func (s *OrderService) RejectOrder(orderID string) error {
+    return s.moderate(orderID, model.OrderStatusRejected)
+}
+
+func (s *OrderService) moderate(orderID, status string) error {
+    order_id, err := parseOrderID(orderID)
+    if err != nil {
+        return err
+    }
+
+    query := `
+        UPDATE orders
+        SET status = $2
+        WHERE id = $1 AND status = $3`
+
+    res, err := s.db.Exec(query, order_id, status, model.OrderStatusPendingReview)
+    if err != nil {
+        return fmt.Errorf("error moderating order: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error moderating order: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %d not found or already moderated: %w", order_id, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+// GetPendingOrders returns orders waiting for moderation, oldest first.
+func (s *OrderService) GetPendingOrders(limit int) ([]model.Order, error) {
+    return s.getOrders(`WHERE o.status = $1 ORDER BY o.created_at LIMIT $2`, model.OrderStatusPendingReview, limit)
+}
+
+// GetOrdersByUser returns a customer's orders, drafts included, newest first.
+func (s *OrderService) GetOrdersByUser(userID int, limit int) ([]model.Order, error) {
+    return s.getOrders(`WHERE o.user_id = $1 ORDER BY o.created_at DESC LIMIT $2`, userID, limit)
+}
+
+// UpdateOrder saves new title, description and location of an order that
+// is still a draft, waiting for review or open, and returns its status. An
+// open order of a customer who has not earned trust yet goes back to
+// moderation, so an approved order cannot be rewritten unchecked.
+func (s *OrderService) UpdateOrder(order model.Order) (string, error) {
+    needsReview, err := s.RequiresModeration(order.User.Id)
+    if err != nil {
+        return "", err
+    }
+
+    query := `
+        UPDATE orders
+        SET title = $2,
+            description = $3,
+            location = $4,
+            status = CASE WHEN status = $7 AND $8 THEN $6 ELSE status END,
+            updated_at = NOW()
+        WHERE id = $1 AND status IN ($5, $6, $7)
+        RETURNING status`
+
+    var status string
+    err = s.db.QueryRow(query,
+        order.ID,
+        order.Title,
+        order.Description,
+        order.Location,
+        model.OrderStatusDraft,
+        model.OrderStatusPendingReview,
+        model.OrderStatusOpen,
+        needsReview,
+    ).Scan(&status)
+    if errors.Is(err, sql.ErrNoRows) {
+        return "", fmt.Errorf("order %d not found or can no longer be edited: %w", order.ID, errs.ErrInvalidState)
+    }
+    if err != nil {
+        return "", fmt.Errorf("error updating order: %w", errs.DB(err))
+    }
+    return status, nil
+}
+
+// PublishOrder takes a draft live. Orders of customers who have not earned
+// trust yet go to moderation instead of straight to executors.
+func (s *OrderService) PublishOrder(orderID string) (model.Order, error) {
+    order, err := s.GetOrderByID(orderID)
+    if err != nil {
+        return model.Order{}, err
+    }
+    if order.Status != model.OrderStatusDraft {
+        return model.Order{}, fmt.Errorf("order %d is not a draft: %w", order.ID, errs.ErrInvalidState)
+    }
+
+    status := model.OrderStatusOpen
+    needsReview, err := s.RequiresModeration(order.User.Id)
+    if err != nil {
+        return model.Order{}, err
+    }
+    if needsReview {
+        status = model.OrderStatusPendingReview
+    }
+
+    // Orders held for review go live, and get published_at, once approved.
+    query := `
+        UPDATE orders
+        SET status = $2,
+            published_at = CASE WHEN $2 = $4 THEN NOW() END
+        WHERE id = $1 AND status = $3
+        RETURNING published_at`
+
+    var publishedAt sql.NullTime
+    err = s.db.QueryRow(query, order.ID, status, model.OrderStatusDraft, model.OrderStatusOpen).Scan(&publishedAt)
+    if errors.Is(err, sql.ErrNoRows) {
+        return model.Order{}, fmt.Errorf("order %d is not a draft: %w", order.ID, errs.ErrInvalidState)
+    }
+    if err != nil {
+        return model.Order{}, fmt.Errorf("error publishing order: %w", err)
+    }
+
+    order.Status = status
+    order.PublishedAt = publishedAt.Time
+    return order, nil
+}
+
+// RepostOrder copies a closed, cancelled or rejected order into a new draft.
+func (s *OrderService) RepostOrder(orderID string) (model.Order, error) {
+    order_id, err := parseOrderID(orderID)
+    if err != nil {
+        return model.Order{}, err
+    }
+
+    tx, err := s.db.Begin()
+    if err != nil {
+        return model.Order{}, fmt.Errorf("error reposting order: %w", err)
+    }
+    defer tx.Rollback()
+
+    query := `
+        INSERT INTO orders (title, description, location, user_id, specialization, status, created_at, reposted_from)
+        SELECT title, description, location, user_id, specialization, $2, NOW(), id
+        FROM orders
+        WHERE id = $1 AND status IN ($3, $4, $5)
+        RETURNING id`
+
+    var newID int
+    err = tx.QueryRow(query, order_id, model.OrderStatusDraft, model.OrderStatusClosed, model.OrderStatusCancelled, model.OrderStatusRejected).Scan(&newID)
+    if errors.Is(err, sql.ErrNoRows) {
+        return model.Order{}, fmt.Errorf("order %d not found or still active: %w", order_id, errs.ErrInvalidState)
+    }
+    if err != nil {
+        return model.Order{}, fmt.Errorf("error reposting order: %w", err)
+    }
+
+    query = `
+        INSERT INTO order_slots (order_id, specialization, headcount)
+        SELECT $2, specialization, headcount
+        FROM order_slots
+        WHERE order_id = $1
+        ORDER BY id`
+
+    if _, err := tx.Exec(query, order_id, newID); err != nil {
+        return model.Order{}, fmt.Errorf("error reposting order: %w", err)
+    }
+
+    if err := tx.Commit(); err != nil {
+        return model.Order{}, fmt.Errorf("error reposting order: %w", err)
+    }
+
+    return s.GetOrderByID(strconv.Itoa(newID))
+}
+
+// HireExecutor records which of the executors who responded to an open
+// order the customer chose. An executor who responded as a team is hired
+// with the team. Crew orders take an executor per slot instead and are
+// staffed once every slot is filled.
+func (s *OrderService) HireExecutor(orderID string, executorID int) error {
+    order, err := s.GetOrderByID(orderID)
+    if err != nil {
+        return err
+    }
+    if len(order.Slots) > 0 {
+        return s.hireForSlot(order.ID, executorID)
+    }
+    order_id := order.ID
+
+    query := `
+        UPDATE orders
+        SET executor_id = $2,
+            team_id = (SELECT MAX(r.team_id) FROM responses r WHERE r.order_id = $1 AND r.user_id = $2),
+            updated_at = NOW()
+        WHERE id = $1
+          AND status = $3
+          AND executor_id IS NULL
+          AND EXISTS (SELECT 1 FROM responses r WHERE r.order_id = $1 AND r.user_id = $2)`
+
+    res, err := s.db.Exec(query, order_id, executorID, model.OrderStatusOpen)
+    if err != nil {
+        return fmt.Errorf("error hiring executor: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error hiring executor: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %d is not open, has an executor or got no response from %d: %w", order_id, executorID, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+// hireForSlot hires an executor for the slot they responded to.
+func (s *OrderService) hireForSlot(orderID, executorID int) error {
+    tx, err := s.db.Begin()
+    if err != nil {
+        return fmt.Errorf("error hiring executor: %w", err)
+    }
+    defer tx.Rollback()
+
+    // Locking the order makes concurrent hires wait, so a slot never gets
+    // more executors than it needs.
+    var status string
+    err = tx.QueryRow(`SELECT status FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&status)
+    if err != nil {
+        return fmt.Errorf("error hiring executor: %w", errs.DB(err))
+    }
+    if status != model.OrderStatusOpen {
+        return fmt.Errorf("order %d is %s: %w", orderID, status, ErrOrderNotOpen)
+    }
+
+    query := `
+        SELECT os.id, os.headcount, (SELECT COUNT(*) FROM slot_hires sh WHERE sh.slot_id = os.id)
+        FROM responses r
+        JOIN order_slots os ON os.id = r.slot_id
+        WHERE r.order_id = $1 AND r.user_id = $2
+        LIMIT 1`
+
+    var slotID, headcount, hired int
+    err = tx.QueryRow(query, orderID, executorID).Scan(&slotID, &headcount, &hired)
+    if errors.Is(err, sql.ErrNoRows) {
+        return fmt.Errorf("order %d got no response from %d: %w", orderID, executorID, errs.ErrInvalidState)
+    }
+    if err != nil {
+        return fmt.Errorf("error hiring executor: %w", err)
+    }
+    if hired >= headcount {
+        return fmt.Errorf("slot %d of order %d: %w", slotID, orderID, ErrSlotFilled)
+    }
+
+    _, err = tx.Exec(`INSERT INTO slot_hires (slot_id, user_id) VALUES ($1, $2)`, slotID, executorID)
+    if err != nil {
+        return fmt.Errorf("error hiring executor: %w", errs.DB(err))
+    }
+
+    query = `
+        UPDATE orders
+        SET status = $2, updated_at = NOW()
+        WHERE id = $1
+          AND NOT EXISTS (
+            SELECT 1 FROM order_slots os
+            WHERE os.order_id = $1
+              AND os.headcount > (SELECT COUNT(*) FROM slot_hires sh WHERE sh.slot_id = os.id))`
+
+    if _, err := tx.Exec(query, orderID, model.OrderStatusStaffed); err != nil {
+        return fmt.Errorf("error staffing order: %w", err)
+    }
+
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("error hiring executor: %w", err)
+    }
+    return nil
+}
+
+// SetSlot sets how many executors of a specialization a draft needs. The
+// first slot turns the order into a crew order that starts from its own
+// specialization, and a headcount of 0 drops the slot. An order left
+// needing a single executor goes back to having no slots.
+func (s *OrderService) SetSlot(orderID string, spec model.Specialization, headcount int) error {
+    if !spec.Valid() || headcount < 0 || headcount > model.MaxSlotHeadcount {
+        return fmt.Errorf("invalid slot %q × %d: %w", spec, headcount, errs.ErrInvalidState)
+    }
+    order, err := s.GetOrderByID(orderID)
+    if err != nil {
+        return err
+    }
+    if order.Status != model.OrderStatusDraft {
+        return fmt.Errorf("order %d is not a draft: %w", order.ID, errs.ErrInvalidState)
+    }
+
+    slots := order.Slots
+    if len(slots) == 0 {
+        slots = []model.OrderSlot{{Specialization: order.Specialization, Headcount: 1}}
+    }
+    var next []model.OrderSlot
+    found := false
+    for _, slot := range slots {
+        if slot.Specialization == spec {
+            slot.Headcount = headcount
+            found = true
+        }
+        if slot.Headcount > 0 {
+            next = append(next, slot)
+        }
+    }
+    if !found && headcount > 0 {
+        next = append(next, model.OrderSlot{Specialization: spec, Headcount: headcount})
+    }
+    if len(next) == 0 {
+        return fmt.Errorf("order %d needs at least one executor: %w", order.ID, errs.ErrInvalidState)
+    }
+
+    tx, err := s.db.Begin()
+    if err != nil {
+        return fmt.Errorf("error saving order slots: %w", err)
+    }
+    defer tx.Rollback()
+
+    // Drafts have no responses or hires yet, so the slots can be rewritten.
+    if _, err := tx.Exec(`DELETE FROM order_slots WHERE order_id = $1`, order.ID); err != nil {
+        return fmt.Errorf("error saving order slots: %w", err)
+    }
+    if len(next) > 1 || next[0].Headcount > 1 {
+        for _, slot := range next {
+            _, err := tx.Exec(`INSERT INTO order_slots (order_id, specialization, headcount) VALUES ($1, $2, $3)`,
+                order.ID, slot.Specialization, slot.Headcount)
+            if err != nil {
+                return fmt.Errorf("error saving order slots: %w", errs.DB(err))
+            }
+        }
+    }
+
+    // The order keeps the specialization of its first slot for the places
+    // that show a single one.
+    query := `
+        UPDATE orders
+        SET specialization = $2, updated_at = NOW()
+        WHERE id = $1 AND status = $3`
+
+    res, err := tx.Exec(query, order.ID, next[0].Specialization, model.OrderStatusDraft)
+    if err != nil {
+        return fmt.Errorf("error saving order slots: %w", errs.DB(err))
+    }
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error saving order slots: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %d is not a draft: %w", order.ID, errs.ErrInvalidState)
+    }
+
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("error saving order slots: %w", err)
+    }
+    return nil
+}
+
+// SearchOpenOrders returns open orders whose title, description or
+// location contain the text, or that need one of the given
+// specializations, newest on the market first. Orders of banned customers
+// are left out.
+func (s *OrderService) SearchOpenOrders(text string, specializations []model.Specialization, limit int) ([]model.Order, error) {
+    specs := make([]string, len(specializations))
+    for i, spec := range specializations {
+        specs[i] = string(spec)
+    }
+
+    return s.getOrders(`
+        WHERE o.status = $1
+          AND NOT u.banned
+          AND (o.title ILIKE $2 OR o.description ILIKE $2 OR o.location ILIKE $2 OR o.specialization = ANY($3)
+               OR EXISTS (SELECT 1 FROM order_slots os WHERE os.order_id = o.id AND os.specialization = ANY($3)))
+        ORDER BY o.published_at DESC
+        LIMIT $4`,
+        model.OrderStatusOpen, like.Contains(text), pq.Array(specs), limit)
+}
+
+func (s *OrderService) getOrders(where string, args ...any) ([]model.Order, error) {
+    query := `
+        SELECT 
+            o.id,
+            o.title,
+            o.description,
+            o.location,
+            o.specialization,
+            o.status,
+            o.created_at,
+            o.published_at,
+            u.id as user_id,
+            u.name,
+            u.user_name,
+            u.chat_id,
+            u.role,
+            COALESCE(ex.portfolio_url, ''),
+            COALESCE(ex.specialization, '') as user_specialization,
+            u.language,
+            COALESCE(o.executor_id, 0),
+            COALESCE(o.team_id, 0)
+        FROM orders o
+        JOIN users u ON o.user_id = u.id
+        LEFT JOIN user_roles ex ON ex.user_id = u.id AND ex.role = 'executor'
+        ` + where
+
+    rows, err := s.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("error getting orders: %w", err)
+    }
+    defer rows.Close()
+
+    var orders []model.Order
+    for rows.Next() {
+        var order model.Order
+        var publishedAt sql.NullTime
+        if err := rows.Scan(
+            &order.ID,
+            &order.Title,
+            &order.Description,
+            &order.Location,
+            &order.Specialization,
+            &order.Status,
+            &order.CreatedAt,
+            &publishedAt,
+            &order.User.Id,
+            &order.User.Name,
+            &order.User.UserName,
+            &order.User.ChatId,
+            &order.User.Role,
+            &order.User.Portfolio,
+            &order.User.Specialization,
+            &order.User.Language,
+            &order.ExecutorID,
+            &order.TeamID,
+        ); err != nil {
+            return nil, fmt.Errorf("error getting orders: %w", err)
+        }
+        order.PublishedAt = publishedAt.Time
+        orders = append(orders, order)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error getting orders: %w", err)
+    }
+
+    if err := s.attachSlots(orders); err != nil {
+        return nil, err
+    }
+    return orders, nil
+}
+
+// attachSlots loads the crew slots of the orders and the executors hired
+// for them.
+func (s *OrderService) attachSlots(orders []model.Order) error {
+    if len(orders) == 0 {
+        return nil
+    }
+    ids := make([]int64, len(orders))
+    index := make(map[int]int, len(orders))
+    for i, order := range orders {
+        ids[i] = int64(order.ID)
+        index[order.ID] = i
+    }
+
+    query := `
+        SELECT
+            os.order_id,
+            os.id,
+            os.specialization,
+            os.headcount,
+            ARRAY(SELECT sh.user_id FROM slot_hires sh WHERE sh.slot_id = os.id ORDER BY sh.created_at)
+        FROM order_slots os
+        WHERE os.order_id = ANY($1)
+        ORDER BY os.id`
+
+    rows, err := s.db.Query(query, pq.Array(ids))
+    if err != nil {
+        return fmt.Errorf("error getting order slots: %w", err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var orderID int
+        var slot model.OrderSlot
+        var hired pq.Int64Array
+        if err := rows.Scan(&orderID, &slot.ID, &slot.Specialization, &slot.Headcount, &hired); err != nil {
+            return fmt.Errorf("error getting order slots: %w", err)
+        }
+        for _, id := range hired {
+            slot.Hired = append(slot.Hired, int(id))
+        }
+        i := index[orderID]
+        orders[i].Slots = append(orders[i].Slots, slot)
+    }
+
+    if err := rows.Err(); err != nil {
+        return fmt.Errorf("error getting order slots: %w", err)
+    }
+    return nil
+}
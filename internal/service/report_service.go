@@ -0,0 +1,142 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+type ReportService struct {
+    db *sql.DB
+}
+
+func NewReportService(db *sql.DB) *ReportService {
+    return &ReportService{db: db}
+}
+
+// CreateReport stores a report and reports whether it is new. Repeated
+// reports from the same user about the same target are ignored, and users
+// holding both roles cannot report themselves.
+func (s *ReportService) CreateReport(reporterID, targetID, orderID int) (bool, error) {
+    if reporterID == targetID {
+        return false, fmt.Errorf("report by user %d: %w", reporterID, ErrSelfReport)
+    }
+
+    query := `
+        INSERT INTO reports(
+            reporter_user_id,
+            target_user_id,
+            order_id,
+            status,
+            created_at
+        ) VALUES ($1, $2, NULLIF($3, 0), $4, NOW())
+        ON CONFLICT DO NOTHING`
+
+    res, err := s.db.Exec(query, reporterID, targetID, orderID, model.ReportStatusPending)
+    if err != nil {
+        return false, fmt.Errorf("error creating report: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return false, fmt.Errorf("error creating report: %w", err)
+    }
+
+    return affected > 0, nil
+}
+
+func (s *ReportService) GetReportByID(reportID int) (model.Report, error) {
+    reports, err := s.getReports(`WHERE r.id = $1`, reportID)
+    if err != nil {
+        return model.Report{}, err
+    }
+    if len(reports) == 0 {
+        return model.Report{}, fmt.Errorf("report %d: %w", reportID, errs.ErrNotFound)
+    }
+    return reports[0], nil
+}
+
+// GetPendingReports returns the oldest unresolved reports first.
+func (s *ReportService) GetPendingReports(limit int) ([]model.Report, error) {
+    return s.getReports(`WHERE r.status = $1 ORDER BY r.created_at LIMIT $2`, model.ReportStatusPending, limit)
+}
+
+func (s *ReportService) ResolveReport(reportID int, status, adminChatID string) error {
+    query := `
+        UPDATE reports
+        SET status = $2, resolved_by = $3, resolved_at = NOW()
+        WHERE id = $1 AND status = $4`
+
+    res, err := s.db.Exec(query, reportID, status, adminChatID, model.ReportStatusPending)
+    if err != nil {
+        return fmt.Errorf("error resolving report: %w", err)
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error resolving report: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("report %d not found or already resolved: %w", reportID, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+func (s *ReportService) getReports(where string, args ...any) ([]model.Report, error) {
+    query := `
+        SELECT
+            r.id,
+            COALESCE(r.order_id, 0),
+            r.status,
+            r.created_at,
+            reporter.id,
+            reporter.name,
+            reporter.user_name,
+            reporter.chat_id,
+            reporter.role,
+            target.id,
+            target.name,
+            target.user_name,
+            target.chat_id,
+            target.role,
+            target.banned
+        FROM reports r
+        JOIN users reporter ON r.reporter_user_id = reporter.id
+        JOIN users target ON r.target_user_id = target.id
+        ` + where
+
+    rows, err := s.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("error getting reports: %w", err)
+    }
+    defer rows.Close()
+
+    var reports []model.Report
+    for rows.Next() {
+        var report model.Report
+        if err := rows.Scan(
+            &report.ID,
+            &report.OrderID,
+            &report.Status,
+            &report.CreatedAt,
+            &report.Reporter.Id,
+            &report.Reporter.Name,
+            &report.Reporter.UserName,
+            &report.Reporter.ChatId,
+            &report.Reporter.Role,
+            &report.Target.Id,
+            &report.Target.Name,
+            &report.Target.UserName,
+            &report.Target.ChatId,
+            &report.Target.Role,
+            &report.Target.Banned,
+        ); err != nil {
+            return nil, fmt.Errorf("error getting reports: %w", err)
+        }
+        reports = append(reports, report)
+    }
+
+    return reports, rows.Err()
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"database/sql"
 	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/model"
 )
 
 type ResponseService struct {
@@ -13,20 +16,62 @@ func NewResponseService(db *sql.DB) *ResponseService {
     return &ResponseService{db: db}
 }
 
-func (s *ResponseService) CreateOrderResponse(orderID string, executorID int) error {
+// CreateOrderResponse stores an executor's response with their note for the
+// customer; an empty note is stored as NULL. teamID is the team the
+// executor responded for, 0 when they responded for themselves.
+func (s *ResponseService) CreateOrderResponse(orderID string, executorID, teamID int, message string) error {
     query := `
         INSERT INTO responses(
             order_id,
             user_id,
+            team_id,
+            slot_id,
+            message,
             created_at
-        ) VALUES ($1, $2, NOW())
+        ) VALUES (
+            $1, $2, NULLIF($3, 0),
+            -- On crew orders the response goes to the executor's slot.
+            (SELECT os.id
+             FROM order_slots os
+             JOIN user_roles ur ON ur.specialization = os.specialization
+             WHERE os.order_id = $1 AND ur.user_id = $2 AND ur.role = 'executor'),
+            NULLIF($4, ''), NOW()
+        )
         RETURNING id`
 
     var responseID int
-    err := s.db.QueryRow(query, orderID, executorID).Scan(&responseID)
+    err := s.db.QueryRow(query, orderID, executorID, teamID, message).Scan(&responseID)
     if err != nil {
-        return fmt.Errorf("error creating order response: %v", err)
+        return fmt.Errorf("error creating order response: %w", errs.DB(err))
     }
 
     return nil
 }
+
+// GetResponders returns the executors who responded to an order, so they can
+// be told when it changes.
+func (s *ResponseService) GetResponders(orderID int) ([]model.User, error) {
+    query := `
+        SELECT DISTINCT u.id, u.name, u.user_name, u.chat_id, u.language
+        FROM responses r
+        JOIN users u ON r.user_id = u.id
+        WHERE r.order_id = $1 AND u.banned = FALSE`
+
+    rows, err := s.db.Query(query, orderID)
+    if err != nil {
+        return nil, fmt.Errorf("error getting responders: %w", err)
+    }
+    defer rows.Close()
+
+    var users []model.User
+    for rows.Next() {
+        var user model.User
+        if err := rows.Scan(&user.Id, &user.Name, &user.UserName, &user.ChatId, &user.Language); err != nil {
+            return nil, fmt.Errorf("error getting responders: %w", err)
+        }
+        user.Role = model.RoleExecutor
+        users = append(users, user)
+    }
+
+    return users, rows.Err()
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+type ReviewService struct {
+    db *sql.DB
+}
+
+func NewReviewService(db *sql.DB) *ReviewService {
+    return &ReviewService{db: db}
+}
+
+// RateExecutor stores a customer's rating of an executor they hired for a
+// closed order, alone or as part of its crew. Each executor is rated once
+// per order.
+func (s *ReviewService) RateExecutor(orderID, customerID, executorID, rating int) error {
+    if rating < 1 || rating > 5 {
+        return fmt.Errorf("invalid rating %d", rating)
+    }
+
+    query := `
+        INSERT INTO reviews(
+            order_id,
+            executor_id,
+            customer_id,
+            rating,
+            created_at
+        )
+        SELECT o.id, $3, o.user_id, $4, NOW()
+        FROM orders o
+        WHERE o.id = $1
+          AND o.user_id = $2
+          AND o.status = $5
+          AND (o.executor_id = $3 OR EXISTS (
+            SELECT 1 FROM order_slots os
+            JOIN slot_hires sh ON sh.slot_id = os.id
+            WHERE os.order_id = o.id AND sh.user_id = $3))`
+
+    res, err := s.db.Exec(query, orderID, customerID, executorID, rating, model.OrderStatusClosed)
+    if err != nil {
+        return fmt.Errorf("error rating executor: %w", errs.DB(err))
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error rating executor: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %d is not a closed order of customer %d that hired %d: %w", orderID, customerID, executorID, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+// GetExecutorStats returns an executor's rating and the number of closed
+// orders they were hired for, crew orders included.
+func (s *ReviewService) GetExecutorStats(executorID int) (model.ExecutorStats, error) {
+    query := `
+        SELECT
+            COALESCE((SELECT AVG(rating) FROM reviews WHERE executor_id = $1), 0),
+            (SELECT COUNT(*) FROM reviews WHERE executor_id = $1),
+            (SELECT COUNT(*) FROM orders o
+             WHERE o.status = $2
+               AND (o.executor_id = $1 OR EXISTS (
+                 SELECT 1 FROM order_slots os
+                 JOIN slot_hires sh ON sh.slot_id = os.id
+                 WHERE os.order_id = o.id AND sh.user_id = $1)))`
+
+    var stats model.ExecutorStats
+    err := s.db.QueryRow(query, executorID, model.OrderStatusClosed).Scan(
+        &stats.Rating,
+        &stats.Reviews,
+        &stats.CompletedJobs,
+    )
+    if err != nil {
+        return model.ExecutorStats{}, fmt.Errorf("error getting executor stats: %w", err)
+    }
+
+    return stats, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"fmt"
+	"strings"
+
+	"github.com/aidosgal/lenshub/internal/errs"
+	"github.com/aidosgal/lenshub/internal/model"
+)
+
+// inviteCodeBytes is the entropy of a team's invite code. The code is all
+// that keeps strangers out of a team, so it has to be hard to guess.
+const inviteCodeBytes = 8
+
+var inviteCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ErrTeamHasOrders refuses to disband a team that still has open orders
+// to finish.
+var ErrTeamHasOrders = fmt.Errorf("team has open orders: %w", errs.ErrInvalidState)
+
+// TeamService manages agencies and studios: teams of executors who respond
+// to orders under one name.
+type TeamService struct {
+    db *sql.DB
+}
+
+func NewTeamService(db *sql.DB) *TeamService {
+    return &TeamService{db: db}
+}
+
+// CreateTeam creates a team owned by the executor. errs.ErrAlreadyExists
+// means the owner already works in a team.
+func (s *TeamService) CreateTeam(name string, ownerID int) (model.Team, error) {
+    code := make([]byte, inviteCodeBytes)
+    if _, err := rand.Read(code); err != nil {
+        return model.Team{}, fmt.Errorf("error generating invite code: %w", err)
+    }
+
+    tx, err := s.db.Begin()
+    if err != nil {
+        return model.Team{}, fmt.Errorf("error creating team: %w", err)
+    }
+    defer tx.Rollback()
+
+    team := model.Team{
+        Name:       name,
+        OwnerID:    ownerID,
+        InviteCode: strings.ToLower(inviteCodeEncoding.EncodeToString(code)),
+    }
+    query := `
+        INSERT INTO teams(
+            name,
+            owner_id,
+            invite_code,
+            created_at
+        ) VALUES ($1, $2, $3, NOW())
+        RETURNING id`
+
+    if err := tx.QueryRow(query, team.Name, team.OwnerID, team.InviteCode).Scan(&team.ID); err != nil {
+        return model.Team{}, fmt.Errorf("error creating team: %w", errs.DB(err))
+    }
+
+    query = `INSERT INTO team_members(team_id, user_id, role, created_at) VALUES ($1, $2, $3, NOW())`
+    if _, err := tx.Exec(query, team.ID, ownerID, model.TeamRoleOwner); err != nil {
+        return model.Team{}, fmt.Errorf("error creating team: %w", errs.DB(err))
+    }
+
+    if err := tx.Commit(); err != nil {
+        return model.Team{}, fmt.Errorf("error creating team: %w", err)
+    }
+    return team, nil
+}
+
+// GetTeam returns a team with its members, the owner first.
+func (s *TeamService) GetTeam(teamID int) (model.Team, error) {
+    query := `
+        SELECT id, name, owner_id, invite_code
+        FROM teams
+        WHERE id = $1`
+
+    var team model.Team
+    err := s.db.QueryRow(query, teamID).Scan(&team.ID, &team.Name, &team.OwnerID, &team.InviteCode)
+    if err != nil {
+        return model.Team{}, fmt.Errorf("error getting team %d: %w", teamID, errs.DB(err))
+    }
+
+    query = `
+        SELECT
+            u.id,
+            u.name,
+            u.user_name,
+            u.chat_id,
+            u.language,
+            COALESCE(ex.portfolio_url, ''),
+            COALESCE(ex.specialization, ''),
+            tm.role
+        FROM team_members tm
+        JOIN users u ON u.id = tm.user_id
+        LEFT JOIN user_roles ex ON ex.user_id = u.id AND ex.role = 'executor'
+        WHERE tm.team_id = $1
+        ORDER BY tm.role = $2 DESC, tm.created_at`
+
+    rows, err := s.db.Query(query, teamID, model.TeamRoleOwner)
+    if err != nil {
+        return model.Team{}, fmt.Errorf("error getting team members: %w", err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var member model.TeamMember
+        if err := rows.Scan(
+            &member.User.Id,
+            &member.User.Name,
+            &member.User.UserName,
+            &member.User.ChatId,
+            &member.User.Language,
+            &member.User.Portfolio,
+            &member.User.Specialization,
+            &member.Role,
+        ); err != nil {
+            return model.Team{}, fmt.Errorf("error getting team members: %w", err)
+        }
+        member.User.TeamID = team.ID
+        member.User.TeamRole = member.Role
+        team.Members = append(team.Members, member)
+    }
+
+    return team, rows.Err()
+}
+
+// GetTeamByInviteCode returns the team an invite link leads to.
+func (s *TeamService) GetTeamByInviteCode(code string) (model.Team, error) {
+    var teamID int
+    err := s.db.QueryRow(`SELECT id FROM teams WHERE invite_code = $1`, code).Scan(&teamID)
+    if err != nil {
+        return model.Team{}, fmt.Errorf("error getting team by invite code: %w", errs.DB(err))
+    }
+    return s.GetTeam(teamID)
+}
+
+// AddMember lets an executor join a team. errs.ErrAlreadyExists means they
+// already work in a team.
+func (s *TeamService) AddMember(teamID, userID int) error {
+    query := `INSERT INTO team_members(team_id, user_id, role, created_at) VALUES ($1, $2, $3, NOW())`
+
+    if _, err := s.db.Exec(query, teamID, userID, model.TeamRoleMember); err != nil {
+        return fmt.Errorf("error adding team member: %w", errs.DB(err))
+    }
+    return nil
+}
+
+// RemoveMember takes a member out of a team. The owner cannot be removed;
+// they disband the team instead.
+func (s *TeamService) RemoveMember(teamID, userID int) error {
+    query := `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2 AND role <> $3`
+
+    res, err := s.db.Exec(query, teamID, userID, model.TeamRoleOwner)
+    if err != nil {
+        return fmt.Errorf("error removing team member: %w", err)
+    }
+    return expectOne(res, "team member %d of team %d not found", userID, teamID)
+}
+
+// SetMemberRole makes a member a manager or takes the role back. The
+// owner's role does not change.
+func (s *TeamService) SetMemberRole(teamID, userID int, role model.TeamRole) error {
+    if !role.Valid() {
+        return fmt.Errorf("invalid team role %q: %w", role, errs.ErrInvalidState)
+    }
+    if role == model.TeamRoleOwner {
+        return fmt.Errorf("a team has one owner: %w", errs.ErrInvalidState)
+    }
+
+    query := `UPDATE team_members SET role = $3 WHERE team_id = $1 AND user_id = $2 AND role <> $4`
+
+    res, err := s.db.Exec(query, teamID, userID, role, model.TeamRoleOwner)
+    if err != nil {
+        return fmt.Errorf("error setting team role: %w", err)
+    }
+    return expectOne(res, "team member %d of team %d not found", userID, teamID)
+}
+
+// DisbandTeam deletes a team. It is refused with ErrTeamHasOrders while an
+// order the team was hired for is open: the order could then no longer be
+// assigned to a member, and its customer would be left waiting.
+func (s *TeamService) DisbandTeam(teamID int) error {
+    query := `
+        DELETE FROM teams t
+        WHERE t.id = $1
+          AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.team_id = t.id AND o.status = $2)`
+
+    res, err := s.db.Exec(query, teamID, model.OrderStatusOpen)
+    if err != nil {
+        return fmt.Errorf("error disbanding team: %w", err)
+    }
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error disbanding team: %w", err)
+    }
+    if affected > 0 {
+        return nil
+    }
+
+    var exists bool
+    if err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM teams WHERE id = $1)`, teamID).Scan(&exists); err != nil {
+        return fmt.Errorf("error disbanding team: %w", err)
+    }
+    if !exists {
+        return fmt.Errorf("team %d not found: %w", teamID, errs.ErrNotFound)
+    }
+    return fmt.Errorf("team %d: %w", teamID, ErrTeamHasOrders)
+}
+
+// AssignMember hands an open order the team was hired for to one of its
+// members.
+func (s *TeamService) AssignMember(orderID string, teamID, userID int) error {
+    query := `
+        UPDATE orders
+        SET executor_id = $3, updated_at = NOW()
+        WHERE id = $1
+          AND team_id = $2
+          AND status = $4
+          AND EXISTS (SELECT 1 FROM team_members tm WHERE tm.team_id = $2 AND tm.user_id = $3)`
+
+    res, err := s.db.Exec(query, orderID, teamID, userID, model.OrderStatusOpen)
+    if err != nil {
+        return fmt.Errorf("error assigning order: %w", errs.DB(err))
+    }
+
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return fmt.Errorf("error assigning order: %w", err)
+    }
+    if affected == 0 {
+        return fmt.Errorf("order %s is not an open order of team %d for member %d: %w", orderID, teamID, userID, errs.ErrInvalidState)
+    }
+    return nil
+}
+
+// GetTeamStats sums up the track record of a team's current members and
+// the orders the team was hired for.
+func (s *TeamService) GetTeamStats(teamID int) (model.ExecutorStats, error) {
+    query := `
+        WITH members AS (SELECT user_id FROM team_members WHERE team_id = $1)
+        SELECT
+            COALESCE((SELECT AVG(rating) FROM reviews WHERE executor_id IN (SELECT user_id FROM members)), 0),
+            (SELECT COUNT(*) FROM reviews WHERE executor_id IN (SELECT user_id FROM members)),
+            (SELECT COUNT(*) FROM orders o
+             WHERE o.status = $2
+               AND (o.team_id = $1
+                 OR o.executor_id IN (SELECT user_id FROM members)
+                 OR EXISTS (
+                   SELECT 1 FROM order_slots os
+                   JOIN slot_hires sh ON sh.slot_id = os.id
+                   WHERE os.order_id = o.id AND sh.user_id IN (SELECT user_id FROM members))))`
+
+    var stats model.ExecutorStats
+    err := s.db.QueryRow(query, teamID, model.OrderStatusClosed).Scan(
+        &stats.Rating,
+        &stats.Reviews,
+        &stats.CompletedJobs,
+    )
+    if err != nil {
+        return model.ExecutorStats{}, fmt.Errorf("error getting team stats: %w", err)
+    }
+
+    return stats, nil
+}
+
+// expectOne turns an update that changed nothing into errs.ErrNotFound.
+func expectOne(res sql.Result, format string, args ...any) error {
+    affected, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if affected == 0 {
+        return fmt.Errorf(format+": %w", append(args, errs.ErrNotFound)...)
+    }
+    return nil
+}
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"fmt"
 
 	"github.com/aidosgal/lenshub/internal/model"
 )
 
 type UserRepository interface {
     CreateUser(user model.User) error
+    AddRole(user model.User) error
+    SetActiveRole(chatID string, role model.Role) error
     GetUserByChatID(string) (*model.User, error)
-    GetUsersBySpecialization(specialization string) ([]model.User, error)
+    GetUserByID(id int) (*model.User, error)
+    GetUsersBySpecialization(specialization model.Specialization) ([]model.User, error)
+    SearchExecutors(text string, specializations []model.Specialization, limit int) ([]model.User, error)
+    SetBanned(chatID string, banned bool) error
+    SetCities(chatID string, cities []string) error
+    GetActiveChatIDs() ([]string, error)
+    SetLanguage(chatID string, language string) error
 }
 
 type UserService struct {
@@ -22,13 +31,66 @@ func NewUserService(repository UserRepository) *UserService {
 }
 
 func (s *UserService) CreateUser(user model.User) error {
+    if err := validateRoleProfile(user); err != nil {
+        return err
+    }
 	return s.repository.CreateUser(user)
 }
 
+// AddRole gives a registered user a second role and switches to it. The
+// role's profile data is taken from user.
+func (s *UserService) AddRole(user model.User) error {
+    if err := validateRoleProfile(user); err != nil {
+        return err
+    }
+    return s.repository.AddRole(user)
+}
+
+func (s *UserService) SetActiveRole(chatID string, role model.Role) error {
+    if !role.Valid() {
+        return fmt.Errorf("invalid role %q", role)
+    }
+    return s.repository.SetActiveRole(chatID, role)
+}
+
+func validateRoleProfile(user model.User) error {
+    if !user.Role.Valid() {
+        return fmt.Errorf("invalid role %q", user.Role)
+    }
+    if user.Role == model.RoleExecutor && !user.Specialization.Valid() {
+        return fmt.Errorf("invalid specialization %q", user.Specialization)
+    }
+    return nil
+}
+
 func (s *UserService) GetUserByChatID(chatID string) (*model.User, error) {
     return s.repository.GetUserByChatID(chatID)
 }
 
-func (s *UserService) GetUsersBySpecialization(specialization string) ([]model.User, error) {
+func (s *UserService) GetUserByID(id int) (*model.User, error) {
+    return s.repository.GetUserByID(id)
+}
+
+func (s *UserService) GetUsersBySpecialization(specialization model.Specialization) ([]model.User, error) {
     return s.repository.GetUsersBySpecialization(specialization)
 }
+
+func (s *UserService) SearchExecutors(text string, specializations []model.Specialization, limit int) ([]model.User, error) {
+    return s.repository.SearchExecutors(text, specializations, limit)
+}
+
+func (s *UserService) SetCities(chatID string, cities []string) error {
+    return s.repository.SetCities(chatID, cities)
+}
+
+func (s *UserService) SetBanned(chatID string, banned bool) error {
+    return s.repository.SetBanned(chatID, banned)
+}
+
+func (s *UserService) GetActiveChatIDs() ([]string, error) {
+    return s.repository.GetActiveChatIDs()
+}
+
+func (s *UserService) SetLanguage(chatID string, language string) error {
+    return s.repository.SetLanguage(chatID, language)
+}
@@ -0,0 +1 @@
+ALTER TABLE responses DROP COLUMN IF EXISTS message;
@@ -0,0 +1 @@
+ALTER TABLE responses ADD COLUMN message VARCHAR(1000) NULL;
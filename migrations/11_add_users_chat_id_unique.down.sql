@@ -0,0 +1 @@
+DROP INDEX IF EXISTS users_chat_id_idx;
@@ -0,0 +1,62 @@
+-- Registering twice, say from a double-tapped button, used to create a
+-- second account for the same chat. Merge such duplicates into the oldest
+-- account first, moving everything that points at the others to it.
+CREATE TEMPORARY TABLE user_merges AS
+SELECT id AS duplicate_id, keep_id
+FROM (
+    SELECT id, MIN(id) OVER (PARTITION BY chat_id) AS keep_id
+    FROM users
+) u
+WHERE id <> keep_id;
+
+UPDATE orders o SET user_id = m.keep_id
+FROM user_merges m WHERE o.user_id = m.duplicate_id;
+
+UPDATE responses r SET user_id = m.keep_id
+FROM user_merges m WHERE r.user_id = m.duplicate_id;
+
+-- The kept account keeps its own role details; it only gains the roles it
+-- did not hold.
+INSERT INTO user_roles (user_id, role, portfolio_url, specialization, created_at)
+SELECT m.keep_id, ur.role, ur.portfolio_url, ur.specialization, ur.created_at
+FROM user_roles ur
+JOIN user_merges m ON ur.user_id = m.duplicate_id
+ORDER BY ur.created_at
+ON CONFLICT (user_id, role) DO NOTHING;
+
+-- A ban on any of the duplicates holds for the merged account.
+UPDATE users u SET banned = TRUE
+FROM user_merges m
+JOIN users d ON d.id = m.duplicate_id
+WHERE u.id = m.keep_id AND d.banned;
+
+-- Reports are unique per reporter, target and order, which the merge may
+-- break, so the index is rebuilt once the extra reports are gone.
+DROP INDEX reports_reporter_target_order_idx;
+
+UPDATE reports r SET reporter_user_id = m.keep_id
+FROM user_merges m WHERE r.reporter_user_id = m.duplicate_id;
+
+UPDATE reports r SET target_user_id = m.keep_id
+FROM user_merges m WHERE r.target_user_id = m.duplicate_id;
+
+DELETE FROM reports r
+USING reports older
+WHERE r.reporter_user_id = older.reporter_user_id
+  AND r.target_user_id = older.target_user_id
+  AND COALESCE(r.order_id, 0) = COALESCE(older.order_id, 0)
+  AND r.id > older.id;
+
+CREATE UNIQUE INDEX reports_reporter_target_order_idx
+    ON reports (reporter_user_id, target_user_id, COALESCE(order_id, 0));
+
+-- The roles of the duplicates go with them.
+DELETE FROM users u
+USING user_merges m
+WHERE u.id = m.duplicate_id;
+
+DROP TABLE user_merges;
+
+-- One account per chat. Registering twice now fails with a unique
+-- violation instead of a second row.
+CREATE UNIQUE INDEX users_chat_id_idx ON users (chat_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS start_link_visits;
+ALTER TABLE users DROP COLUMN IF EXISTS referred_by;
+ALTER TABLE users DROP COLUMN IF EXISTS source;
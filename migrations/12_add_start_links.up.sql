@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN source VARCHAR(64) NULL;
+ALTER TABLE users ADD COLUMN referred_by INT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE start_link_visits (
+    id SERIAL PRIMARY KEY,
+    chat_id VARCHAR(255) NOT NULL,
+    payload VARCHAR(64) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    registered BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX start_link_visits_payload_idx ON start_link_visits (payload);
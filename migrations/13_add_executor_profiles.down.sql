@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reviews;
+ALTER TABLE orders DROP COLUMN IF EXISTS executor_id;
+ALTER TABLE user_roles DROP COLUMN IF EXISTS cities;
@@ -0,0 +1,16 @@
+ALTER TABLE user_roles ADD COLUMN cities TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE orders ADD COLUMN executor_id INT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    executor_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    customer_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT reviews_rating_check CHECK (rating BETWEEN 1 AND 5)
+);
+
+CREATE UNIQUE INDEX reviews_order_idx ON reviews (order_id);
+CREATE INDEX reviews_executor_idx ON reviews (executor_id);
+CREATE INDEX orders_executor_idx ON orders (executor_id) WHERE executor_id IS NOT NULL;
@@ -0,0 +1,7 @@
+CREATE TABLE channel_posts (
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    message_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_id, chat_id)
+);
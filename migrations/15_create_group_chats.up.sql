@@ -0,0 +1,9 @@
+CREATE TABLE group_chats (
+    chat_id BIGINT PRIMARY KEY,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    linked_by BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX group_chats_user_idx ON group_chats (user_id);
@@ -0,0 +1,22 @@
+CREATE TABLE teams (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invite_code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE team_members (
+    team_id INT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(32) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, user_id),
+    CONSTRAINT team_members_role_check CHECK (role IN ('owner', 'manager', 'member'))
+);
+
+-- An executor works in one team at most.
+CREATE UNIQUE INDEX team_members_user_idx ON team_members (user_id);
+
+ALTER TABLE responses ADD COLUMN team_id INT NULL REFERENCES teams(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN team_id INT NULL REFERENCES teams(id) ON DELETE SET NULL;
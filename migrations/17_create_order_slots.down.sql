@@ -0,0 +1,3 @@
+ALTER TABLE responses DROP COLUMN IF EXISTS slot_id;
+DROP TABLE IF EXISTS slot_hires;
+DROP TABLE IF EXISTS order_slots;
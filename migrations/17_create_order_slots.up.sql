@@ -0,0 +1,21 @@
+CREATE TABLE order_slots (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    specialization VARCHAR(255) NOT NULL,
+    headcount SMALLINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT order_slots_specialization_check
+        CHECK (specialization IN ('videographer', 'photographer', 'motion_designer', 'graphic_designer')),
+    CONSTRAINT order_slots_headcount_check CHECK (headcount BETWEEN 1 AND 10)
+);
+
+CREATE UNIQUE INDEX order_slots_order_idx ON order_slots (order_id, specialization);
+
+CREATE TABLE slot_hires (
+    slot_id INT NOT NULL REFERENCES order_slots(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (slot_id, user_id)
+);
+
+ALTER TABLE responses ADD COLUMN slot_id INT NULL REFERENCES order_slots(id) ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS reviews_order_executor_idx;
+DELETE FROM reviews r
+USING reviews older
+WHERE r.order_id = older.order_id AND r.id > older.id;
+CREATE UNIQUE INDEX reviews_order_idx ON reviews (order_id);
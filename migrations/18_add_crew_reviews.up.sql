@@ -0,0 +1,3 @@
+-- Every executor hired for a crew order is rated on their own.
+DROP INDEX IF EXISTS reviews_order_idx;
+CREATE UNIQUE INDEX reviews_order_executor_idx ON reviews (order_id, executor_id);
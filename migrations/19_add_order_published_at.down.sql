@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,6 @@
+-- Set when an order first goes live. An edit that sends a live order back
+-- to moderation keeps it, so approving the edit does not announce the order
+-- to executors and channels a second time.
+ALTER TABLE orders ADD COLUMN published_at TIMESTAMP NULL;
+
+UPDATE orders SET published_at = created_at WHERE status IN ('open', 'staffed', 'closed');
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS admin_audit_log;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS closed_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
+
+ALTER TABLE users DROP COLUMN IF EXISTS banned;
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN banned BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE orders ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'open';
+ALTER TABLE orders ADD COLUMN closed_at TIMESTAMP NULL;
+
+CREATE TABLE admin_audit_log (
+    id SERIAL PRIMARY KEY,
+    admin_chat_id VARCHAR(255) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target VARCHAR(255) NULL,
+    details VARCHAR(10000) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,14 @@
+CREATE TABLE reports (
+    id SERIAL PRIMARY KEY,
+    reporter_user_id INT NOT NULL,
+    target_user_id INT NOT NULL,
+    order_id INT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    resolved_by VARCHAR(255) NULL,
+    resolved_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX reports_reporter_target_order_idx
+    ON reports (reporter_user_id, target_user_id, COALESCE(order_id, 0));
+CREATE INDEX reports_status_idx ON reports (status);
@@ -0,0 +1,4 @@
+UPDATE users SET role = 'Заказчик' WHERE role = 'customer';
+UPDATE users SET role = 'Исполнитель' WHERE role = 'executor';
+
+ALTER TABLE users DROP COLUMN IF EXISTS language;
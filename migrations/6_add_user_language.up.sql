@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN language VARCHAR(8) NOT NULL DEFAULT 'ru';
+
+UPDATE users SET role = 'customer' WHERE role = 'Заказчик';
+UPDATE users SET role = 'executor' WHERE role = 'Исполнитель';
@@ -0,0 +1,16 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_specialization_check;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_executor_specialization_check;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_specialization_check;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+
+UPDATE orders SET specialization = 'Видеооператор' WHERE specialization = 'videographer';
+UPDATE orders SET specialization = 'Фотограф' WHERE specialization = 'photographer';
+UPDATE orders SET specialization = 'Motion Дизайнер' WHERE specialization = 'motion_designer';
+UPDATE orders SET specialization = 'Графический Дизайнер' WHERE specialization = 'graphic_designer';
+
+UPDATE users SET specialization = '' WHERE specialization IS NULL;
+UPDATE users SET specialization = 'Видеооператор' WHERE specialization = 'videographer';
+UPDATE users SET specialization = 'Фотограф' WHERE specialization = 'photographer';
+UPDATE users SET specialization = 'Motion Дизайнер' WHERE specialization = 'motion_designer';
+UPDATE users SET specialization = 'Графический Дизайнер' WHERE specialization = 'graphic_designer';
@@ -0,0 +1,20 @@
+UPDATE users SET specialization = 'videographer' WHERE specialization = 'Видеооператор';
+UPDATE users SET specialization = 'photographer' WHERE specialization = 'Фотограф';
+UPDATE users SET specialization = 'motion_designer' WHERE specialization = 'Motion Дизайнер';
+UPDATE users SET specialization = 'graphic_designer' WHERE specialization = 'Графический Дизайнер';
+UPDATE users SET specialization = NULL WHERE specialization = '';
+
+UPDATE orders SET specialization = 'videographer' WHERE specialization = 'Видеооператор';
+UPDATE orders SET specialization = 'photographer' WHERE specialization = 'Фотограф';
+UPDATE orders SET specialization = 'motion_designer' WHERE specialization = 'Motion Дизайнер';
+UPDATE orders SET specialization = 'graphic_designer' WHERE specialization = 'Графический Дизайнер';
+
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('customer', 'executor'));
+ALTER TABLE users ADD CONSTRAINT users_specialization_check
+    CHECK (specialization IN ('videographer', 'photographer', 'motion_designer', 'graphic_designer'));
+ALTER TABLE users ADD CONSTRAINT users_executor_specialization_check
+    CHECK (role <> 'executor' OR specialization IS NOT NULL);
+
+ALTER TABLE orders ADD CONSTRAINT orders_specialization_check
+    CHECK (specialization IN ('videographer', 'photographer', 'motion_designer', 'graphic_designer'));
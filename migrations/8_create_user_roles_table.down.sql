@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN portfolio_url VARCHAR(255) NULL;
+ALTER TABLE users ADD COLUMN specialization VARCHAR(255) NULL;
+
+UPDATE users u
+SET portfolio_url = r.portfolio_url,
+    specialization = r.specialization
+FROM user_roles r
+WHERE r.user_id = u.id AND r.role = u.role;
+
+ALTER TABLE users ADD CONSTRAINT users_specialization_check
+    CHECK (specialization IN ('videographer', 'photographer', 'motion_designer', 'graphic_designer'));
+ALTER TABLE users ADD CONSTRAINT users_executor_specialization_check
+    CHECK (role <> 'executor' OR specialization IS NOT NULL);
+
+DROP TABLE IF EXISTS user_roles;
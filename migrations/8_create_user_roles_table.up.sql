@@ -0,0 +1,26 @@
+CREATE TABLE user_roles (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(255) NOT NULL,
+    portfolio_url VARCHAR(255) NULL,
+    specialization VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role),
+    CONSTRAINT user_roles_role_check
+        CHECK (role IN ('customer', 'executor')),
+    CONSTRAINT user_roles_specialization_check
+        CHECK (specialization IN ('videographer', 'photographer', 'motion_designer', 'graphic_designer')),
+    CONSTRAINT user_roles_executor_specialization_check
+        CHECK (role <> 'executor' OR specialization IS NOT NULL)
+);
+
+CREATE INDEX user_roles_specialization_idx ON user_roles (specialization) WHERE role = 'executor';
+
+INSERT INTO user_roles (user_id, role, portfolio_url, specialization, created_at)
+SELECT id, role, NULLIF(portfolio_url, ''), specialization, created_at
+FROM users;
+
+-- users.role now only records which of the held roles is active.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_executor_specialization_check;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_specialization_check;
+ALTER TABLE users DROP COLUMN portfolio_url;
+ALTER TABLE users DROP COLUMN specialization;
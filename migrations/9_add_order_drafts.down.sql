@@ -0,0 +1,4 @@
+DELETE FROM orders WHERE status = 'draft';
+
+ALTER TABLE orders DROP COLUMN IF EXISTS reposted_from;
+ALTER TABLE orders DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN updated_at TIMESTAMP NULL;
+ALTER TABLE orders ADD COLUMN reposted_from INT NULL REFERENCES orders(id) ON DELETE SET NULL;